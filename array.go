@@ -0,0 +1,655 @@
+package json
+
+import (
+	"context"
+	"fmt"
+	"github.com/0xor1/panic"
+	"sort"
+	"strings"
+)
+
+// Stream sends each element of the array at `path` on the returned `*Json` channel,
+// closing both channels once the array is exhausted. Navigation and type errors,
+// as well as `ctx` cancellation, are reported on the buffered error channel.
+//
+//		items, errs := js.Stream(ctx, "results")
+//		for item := range items {
+//			fmt.Println(item.MustString("name"))
+//		}
+//		if err := <-errs; err != nil {
+//			return err
+//		}
+func (j *Json) Stream(ctx context.Context, path ...interface{}) (<-chan *Json, <-chan error) {
+	out := make(chan *Json)
+	errs := make(chan error, 1)
+	go func() {
+		defer close(errs)
+		defer close(out)
+		a, err := j.Slice(path...)
+		if err != nil {
+			errs <- err
+			return
+		}
+		for _, v := range a {
+			select {
+			case out <- j.wrap(v):
+			case <-ctx.Done():
+				errs <- ctx.Err()
+				return
+			}
+		}
+	}()
+	return out, errs
+}
+
+// UnionKeys returns the sorted union of the keys of every object in the array at `path`,
+// an error is returned if any element is not an object
+func (j *Json) UnionKeys(path ...interface{}) ([]string, error) {
+	ms, err := j.MapSlice(path...)
+	if err != nil {
+		return nil, err
+	}
+	set := map[string]struct{}{}
+	for _, m := range ms {
+		for k := range m {
+			set[k] = struct{}{}
+		}
+	}
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// MustUnionKeys is a call to UnionKeys with a panic on none nil error
+func (j *Json) MustUnionKeys(path ...interface{}) []string {
+	keys, err := j.UnionKeys(path...)
+	panic.IfNotNil(err)
+	return keys
+}
+
+// SchemaDrift compares the shape of the array of objects at `path` in `j` with that
+// in `other`, taken to be the earlier version, and returns a sorted, human readable
+// description of each top level key that has been added, removed, or has changed in
+// the set of kinds of value it holds
+//		[`key "id" type changed from number to string`, `key "name" removed (string)`]
+func (j *Json) SchemaDrift(other *Json, path ...interface{}) ([]string, error) {
+	shape, err := j.recordShape(path...)
+	if err != nil {
+		return nil, err
+	}
+	prev, err := other.recordShape(path...)
+	if err != nil {
+		return nil, fmt.Errorf("other: %s", err)
+	}
+	keys := make([]string, 0, len(shape)+len(prev))
+	for k := range shape {
+		keys = append(keys, k)
+	}
+	for k := range prev {
+		if _, exists := shape[k]; !exists {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	drift := []string{}
+	for _, k := range keys {
+		now, inNow := shape[k]
+		was, inWas := prev[k]
+		switch {
+		case !inWas:
+			drift = append(drift, fmt.Sprintf("key %q added (%s)", k, now))
+		case !inNow:
+			drift = append(drift, fmt.Sprintf("key %q removed (%s)", k, was))
+		case now != was:
+			drift = append(drift, fmt.Sprintf("key %q type changed from %s to %s", k, was, now))
+		}
+	}
+	return drift, nil
+}
+
+// MustSchemaDrift is a call to SchemaDrift with a panic on none nil error
+func (j *Json) MustSchemaDrift(other *Json, path ...interface{}) []string {
+	drift, err := j.SchemaDrift(other, path...)
+	panic.IfNotNil(err)
+	return drift
+}
+
+// recordShape returns each key from UnionKeys of the array at `path` mapped to the
+// sorted `|` separated names of the kinds of value it holds, e.g. "null|number"
+func (j *Json) recordShape(path ...interface{}) (map[string]string, error) {
+	ms, err := j.MapSlice(path...)
+	if err != nil {
+		return nil, err
+	}
+	keys, _ := j.UnionKeys(path...)
+	shape := make(map[string]string, len(keys))
+	for _, k := range keys {
+		kinds := map[string]struct{}{}
+		for _, m := range ms {
+			if v, exists := m[k]; exists {
+				kind, err := kindOf(v)
+				if err != nil {
+					return nil, err
+				}
+				kinds[kind.String()] = struct{}{}
+			}
+		}
+		names := make([]string, 0, len(kinds))
+		for name := range kinds {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		shape[k] = strings.Join(names, "|")
+	}
+	return shape, nil
+}
+
+// Rectangularize ensures every object in the array at `path` has the full set of keys
+// returned by UnionKeys, inserting a copy of `fill` for any key an object is missing
+func (j *Json) Rectangularize(fill interface{}, path ...interface{}) error {
+	ms, err := j.MapSlice(path...)
+	if err != nil {
+		return err
+	}
+	keys, _ := j.UnionKeys(path...)
+	for _, m := range ms {
+		for _, k := range keys {
+			if _, exists := m[k]; !exists {
+				m[k] = cloneData(fill)
+			}
+		}
+	}
+	return nil
+}
+
+// MustRectangularize is a call to Rectangularize with a panic on none nil error
+func (j *Json) MustRectangularize(fill interface{}, path ...interface{}) {
+	panic.IfNotNil(j.Rectangularize(fill, path...))
+}
+
+// IndexBy returns a new `Json` object mapping the value found at `key` in each object
+// of the array at `path` to a copy of that object, where later objects win over earlier
+// ones with the same key value. `key` is a dotted path such as `user.id`, and the values
+// found must be strings, numbers or bools.
+//		[{"id":"a","v":1},{"id":"b","v":2}] => {"a":{"id":"a","v":1},"b":{"id":"b","v":2}}
+func (j *Json) IndexBy(key string, path ...interface{}) (*Json, error) {
+	ms, err := j.MapSlice(path...)
+	if err != nil {
+		return nil, err
+	}
+	keyPath := parseDottedPath(key)
+	index := make(map[string]interface{}, len(ms))
+	for i, m := range ms {
+		k, err := indexKey(&Json{data: m}, keyPath)
+		if err != nil {
+			return nil, fmt.Errorf("element %d: %w", i, err)
+		}
+		index[k] = cloneData(m)
+	}
+	return &Json{data: index}, nil
+}
+
+// MustIndexBy is a call to IndexBy with a panic on none nil error
+func (j *Json) MustIndexBy(key string, path ...interface{}) *Json {
+	js, err := j.IndexBy(key, path...)
+	panic.IfNotNil(err)
+	return js
+}
+
+// ToArrayFromKeyed is the inverse of IndexBy, returning a new `Json` array of copies
+// of the values of the object at `path`, ordered by their sorted keys
+func (j *Json) ToArrayFromKeyed(path ...interface{}) (*Json, error) {
+	m, err := j.Map(path...)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	arr := make([]interface{}, 0, len(keys))
+	for _, k := range keys {
+		arr = append(arr, cloneData(m[k]))
+	}
+	return &Json{data: arr}, nil
+}
+
+// MustToArrayFromKeyed is a call to ToArrayFromKeyed with a panic on none nil error
+func (j *Json) MustToArrayFromKeyed(path ...interface{}) *Json {
+	js, err := j.ToArrayFromKeyed(path...)
+	panic.IfNotNil(err)
+	return js
+}
+
+// indexKey returns the string form of the scalar value at `keyPath` in `elem`
+func indexKey(elem *Json, keyPath []interface{}) (string, error) {
+	v, err := elem.Interface(keyPath...)
+	if err != nil {
+		return "", err
+	}
+	if v == nil || !isScalar(v) {
+		return "", fmt.Errorf("value at %q is not a string, number or bool", dottedPath(keyPath))
+	}
+	return fmt.Sprint(v), nil
+}
+
+// UpsertByKey replaces the first object in the array at `path` whose value at `key`
+// equals that of `element`, or appends `element` if there is no such object.
+// `key` is a dotted path such as `user.id`.
+func (j *Json) UpsertByKey(key string, element *Json, path ...interface{}) error {
+	ms, err := j.MapSlice(path...)
+	if err != nil {
+		return err
+	}
+	keyPath := parseDottedPath(key)
+	if _, err := indexKey(element, keyPath); err != nil {
+		return fmt.Errorf("element: %s", err)
+	}
+	elemKey := element.MustInterface(keyPath...)
+	arr, _ := j.Slice(path...)
+	for i, m := range ms {
+		if v, err := (&Json{data: m}).Interface(keyPath...); err == nil && equalScalar(v, elemKey) {
+			arr[i] = element.data
+			return nil
+		}
+	}
+	return j.setAt(path, append(arr, element.data))
+}
+
+// MustUpsertByKey is a call to UpsertByKey with a panic on none nil error
+func (j *Json) MustUpsertByKey(key string, element *Json, path ...interface{}) {
+	panic.IfNotNil(j.UpsertByKey(key, element, path...))
+}
+
+// RemoveWhere removes every element of the array at `path` for which `pred` returns
+// true, returning the number of elements removed
+func (j *Json) RemoveWhere(pred func(*Json) bool, path ...interface{}) (int, error) {
+	arr, err := j.Slice(path...)
+	if err != nil {
+		return 0, err
+	}
+	kept := make([]interface{}, 0, len(arr))
+	for _, v := range arr {
+		if !pred(j.wrap(v)) {
+			kept = append(kept, v)
+		}
+	}
+	if err := j.setAt(path, kept); err != nil {
+		return 0, err
+	}
+	return len(arr) - len(kept), nil
+}
+
+// MustRemoveWhere is a call to RemoveWhere with a panic on none nil error
+func (j *Json) MustRemoveWhere(pred func(*Json) bool, path ...interface{}) int {
+	n, err := j.RemoveWhere(pred, path...)
+	panic.IfNotNil(err)
+	return n
+}
+
+// Dedupe removes every element of the array at `path` that is equal to an earlier
+// one, keeping the first occurrences in order. Elements are compared by their
+// canonical encodings, as by ToCanonicalBytes, so numbers are equal by value and
+// objects regardless of key order.
+//		[1,"1",1.0,{"a":1,"b":2},{"b":2,"a":1}] => [1,"1",{"a":1,"b":2}]
+func (j *Json) Dedupe(path ...interface{}) error {
+	arr, err := j.Slice(path...)
+	if err != nil {
+		return err
+	}
+	seen := make(map[string]bool, len(arr))
+	kept := make([]interface{}, 0, len(arr))
+	for i, v := range arr {
+		b, err := (&Json{data: v}).ToCanonicalBytes()
+		if err != nil {
+			return fmt.Errorf("element %d: %w", i, err)
+		}
+		if !seen[string(b)] {
+			seen[string(b)] = true
+			kept = append(kept, v)
+		}
+	}
+	return j.setAt(path, kept)
+}
+
+// MustDedupe is a call to Dedupe with a panic on none nil error
+func (j *Json) MustDedupe(path ...interface{}) {
+	panic.IfNotNil(j.Dedupe(path...))
+}
+
+// Partition splits the array at `path` into two new arrays of copies of its elements,
+// those for which `pred` returns true and the rest, leaving the source unmodified.
+// Both results are new documents that share no data with `j`.
+func (j *Json) Partition(pred func(*Json) bool, path ...interface{}) (matched, rest *Json, err error) {
+	arr, err := j.Slice(path...)
+	if err != nil {
+		return nil, nil, err
+	}
+	m, r := []interface{}{}, []interface{}{}
+	for _, v := range arr {
+		if pred(j.wrap(v)) {
+			m = append(m, cloneData(v))
+		} else {
+			r = append(r, cloneData(v))
+		}
+	}
+	return j.wrap(m), j.wrap(r), nil
+}
+
+// MustPartition is a call to Partition with a panic on none nil error
+func (j *Json) MustPartition(pred func(*Json) bool, path ...interface{}) (matched, rest *Json) {
+	matched, rest, err := j.Partition(pred, path...)
+	panic.IfNotNil(err)
+	return matched, rest
+}
+
+// Filter returns a new array of copies of the elements of the array at `path` for
+// which `fn` returns true, leaving the source unmodified
+//		adults, err := js.Filter(func(i int, u *json.Json) bool { return u.IntOrDefault(0, "age") >= 18 }, "users")
+func (j *Json) Filter(fn func(index int, value *Json) bool, path ...interface{}) (*Json, error) {
+	arr, err := j.Slice(path...)
+	if err != nil {
+		return nil, err
+	}
+	kept := []interface{}{}
+	for i, v := range arr {
+		if fn(i, j.wrap(v)) {
+			kept = append(kept, cloneData(v))
+		}
+	}
+	return j.wrap(kept), nil
+}
+
+// MustFilter is a call to Filter with a panic on none nil error
+func (j *Json) MustFilter(fn func(index int, value *Json) bool, path ...interface{}) *Json {
+	js, err := j.Filter(fn, path...)
+	panic.IfNotNil(err)
+	return js
+}
+
+// MapEach returns a new array holding the result of calling `fn` on each element of
+// the array at `path`, stopping at and returning the first error from `fn`
+//		names, err := js.MapEach(func(i int, u *json.Json) (interface{}, error) { return u.String("name") }, "users")
+func (j *Json) MapEach(fn func(index int, value *Json) (interface{}, error), path ...interface{}) (*Json, error) {
+	arr, err := j.Slice(path...)
+	if err != nil {
+		return nil, err
+	}
+	mapped := make([]interface{}, 0, len(arr))
+	for i, v := range arr {
+		m, err := fn(i, j.wrap(v))
+		if err != nil {
+			return nil, fmt.Errorf("element %d: %w", i, err)
+		}
+		mapped = append(mapped, m)
+	}
+	return j.wrap(mapped), nil
+}
+
+// MustMapEach is a call to MapEach with a panic on none nil error
+func (j *Json) MustMapEach(fn func(index int, value *Json) (interface{}, error), path ...interface{}) *Json {
+	js, err := j.MapEach(fn, path...)
+	panic.IfNotNil(err)
+	return js
+}
+
+// Stats holds aggregate statistics of a numeric array
+type Stats struct {
+	Count int
+	Sum   float64
+	Min   float64
+	Max   float64
+	Mean  float64
+}
+
+// Stats computes aggregate statistics over the array at `path`, coercing each element
+// as Float64 does. An empty array results in a zero `Stats`.
+func (j *Json) Stats(path ...interface{}) (Stats, error) {
+	var stats Stats
+	arr, err := j.Slice(path...)
+	if err != nil {
+		return stats, err
+	}
+	for i, v := range arr {
+		f, err := j.wrap(v).Float64()
+		if err != nil {
+			return Stats{}, fmt.Errorf("element %d: %w", i, err)
+		}
+		if i == 0 || f < stats.Min {
+			stats.Min = f
+		}
+		if i == 0 || f > stats.Max {
+			stats.Max = f
+		}
+		stats.Sum += f
+		stats.Count++
+	}
+	if stats.Count > 0 {
+		stats.Mean = stats.Sum / float64(stats.Count)
+	}
+	return stats, nil
+}
+
+// MustStats is a call to Stats with a panic on none nil error
+func (j *Json) MustStats(path ...interface{}) Stats {
+	stats, err := j.Stats(path...)
+	panic.IfNotNil(err)
+	return stats
+}
+
+// Pluck returns a new array holding a copy of the value at `field` in each element
+// of the array at `path`, or null for elements that do not have one. `field` is a
+// dotted path such as `user.name`.
+//		[{"id":1},{"id":2},{}] => [1,2,null]
+func (j *Json) Pluck(field string, path ...interface{}) (*Json, error) {
+	arr, err := j.Slice(path...)
+	if err != nil {
+		return nil, err
+	}
+	fieldPath := parseDottedPath(field)
+	plucked := make([]interface{}, 0, len(arr))
+	for _, v := range arr {
+		f, err := j.wrap(v).Interface(fieldPath...)
+		if err != nil {
+			f = nil
+		}
+		plucked = append(plucked, cloneData(f))
+	}
+	return j.wrap(plucked), nil
+}
+
+// MustPluck is a call to Pluck with a panic on none nil error
+func (j *Json) MustPluck(field string, path ...interface{}) *Json {
+	js, err := j.Pluck(field, path...)
+	panic.IfNotNil(err)
+	return js
+}
+
+// Chunk returns a new array of arrays holding copies of the elements of the array at
+// `path` in order, each of `size` elements except the final one which holds whatever
+// remain. An empty array results in an empty array of chunks.
+//		[1,2,3,4,5] => [[1,2],[3,4],[5]]
+func (j *Json) Chunk(size int, path ...interface{}) (*Json, error) {
+	if size <= 0 {
+		return nil, fmt.Errorf("chunk size must be greater than 0")
+	}
+	arr, err := j.Slice(path...)
+	if err != nil {
+		return nil, err
+	}
+	chunks := make([]interface{}, 0, (len(arr)+size-1)/size)
+	for i := 0; i < len(arr); i += size {
+		end := i + size
+		if end > len(arr) {
+			end = len(arr)
+		}
+		chunks = append(chunks, cloneData(arr[i:end]))
+	}
+	return j.wrap(chunks), nil
+}
+
+// MustChunk is a call to Chunk with a panic on none nil error
+func (j *Json) MustChunk(size int, path ...interface{}) *Json {
+	js, err := j.Chunk(size, path...)
+	panic.IfNotNil(err)
+	return js
+}
+
+// Reverse reverses the order of the elements of the array at `path` in place, an
+// empty `path` addresses the root array
+func (j *Json) Reverse(path ...interface{}) error {
+	arr, err := j.Slice(path...)
+	if err != nil {
+		return err
+	}
+	for i, k := 0, len(arr)-1; i < k; i, k = i+1, k-1 {
+		arr[i], arr[k] = arr[k], arr[i]
+	}
+	return nil
+}
+
+// MustReverse is a call to Reverse with a panic on none nil error
+func (j *Json) MustReverse(path ...interface{}) {
+	panic.IfNotNil(j.Reverse(path...))
+}
+
+// ReverseSlice is a call to Reverse
+//
+// Deprecated: use Reverse
+func (j *Json) ReverseSlice(path ...interface{}) error {
+	return j.Reverse(path...)
+}
+
+// MustReverseSlice is a call to ReverseSlice with a panic on none nil error
+//
+// Deprecated: use MustReverse
+func (j *Json) MustReverseSlice(path ...interface{}) {
+	panic.IfNotNil(j.ReverseSlice(path...))
+}
+
+// SortSlice sorts the elements of the array at `path` in place using `less`, the
+// sort is stable so elements that are equal keep their original order
+//		js.SortSlice(func(a, b *json.Json) bool { return a.MustInt("rank") < b.MustInt("rank") }, "results")
+func (j *Json) SortSlice(less func(a, b *Json) bool, path ...interface{}) error {
+	arr, err := j.Slice(path...)
+	if err != nil {
+		return err
+	}
+	sort.SliceStable(arr, func(x, y int) bool {
+		return less(j.wrap(arr[x]), j.wrap(arr[y]))
+	})
+	return nil
+}
+
+// MustSortSlice is a call to SortSlice with a panic on none nil error
+func (j *Json) MustSortSlice(less func(a, b *Json) bool, path ...interface{}) {
+	panic.IfNotNil(j.SortSlice(less, path...))
+}
+
+// SortSliceByKey is a call to SortSlice ordering elements by the value at `key`, a
+// dotted path such as `user.age`, numbers are compared numerically and strings
+// lexically. Values of different kinds are ordered null, bool, number, string, array
+// then object, as by CanonicalUnordered, with a missing value treated as null.
+//		js.SortSliceByKey("name", true, "users")
+func (j *Json) SortSliceByKey(key string, ascending bool, path ...interface{}) error {
+	keyPath := parseDottedPath(key)
+	return j.SortSlice(func(a, b *Json) bool {
+		av, err := a.Interface(keyPath...)
+		if err != nil {
+			av = nil
+		}
+		bv, err := b.Interface(keyPath...)
+		if err != nil {
+			bv = nil
+		}
+		ak, _ := kindOf(av)
+		bk, _ := kindOf(bv)
+		c := int(ak) - int(bk)
+		if c == 0 {
+			c = compareUnordered(av, bv, nil)
+		}
+		if ascending {
+			return c < 0
+		}
+		return c > 0
+	}, path...)
+}
+
+// MustSortSliceByKey is a call to SortSliceByKey with a panic on none nil error
+func (j *Json) MustSortSliceByKey(key string, ascending bool, path ...interface{}) {
+	panic.IfNotNil(j.SortSliceByKey(key, ascending, path...))
+}
+
+// FlattenArray replaces the array at `path` with one in which each element that is
+// itself an array is replaced by its elements, other elements are kept as they are
+//		[[1,2],3,[[4]]] => [1,2,3,[4]]
+func (j *Json) FlattenArray(path ...interface{}) error {
+	return j.flattenArray(1, path)
+}
+
+// MustFlattenArray is a call to FlattenArray with a panic on none nil error
+func (j *Json) MustFlattenArray(path ...interface{}) {
+	panic.IfNotNil(j.FlattenArray(path...))
+}
+
+// FlattenArrayDeep is like FlattenArray but flattens nested arrays recursively so
+// that no element of the resulting array is an array
+//		[[1,2],3,[[4]]] => [1,2,3,4]
+func (j *Json) FlattenArrayDeep(path ...interface{}) error {
+	return j.flattenArray(-1, path)
+}
+
+// MustFlattenArrayDeep is a call to FlattenArrayDeep with a panic on none nil error
+func (j *Json) MustFlattenArrayDeep(path ...interface{}) {
+	panic.IfNotNil(j.FlattenArrayDeep(path...))
+}
+
+func (j *Json) flattenArray(depth int, path []interface{}) error {
+	arr, err := j.Slice(path...)
+	if err != nil {
+		return err
+	}
+	return j.setAt(path, flattenSlice(make([]interface{}, 0, len(arr)), arr, depth))
+}
+
+// flattenSlice appends the elements of `arr` to `dst`, expanding nested arrays up to
+// `depth` levels, or without limit if `depth` is negative
+func flattenSlice(dst, arr []interface{}, depth int) []interface{} {
+	for _, v := range arr {
+		if inner, ok := v.([]interface{}); ok && depth != 0 {
+			dst = flattenSlice(dst, inner, depth-1)
+		} else {
+			dst = append(dst, v)
+		}
+	}
+	return dst
+}
+
+// ZipMerge deep merges each object in the array at `path` in `other` into the object
+// at the same index in the array at `path` in `j`, the arrays must be the same length
+func (j *Json) ZipMerge(other *Json, path ...interface{}) error {
+	dst, err := j.MapSlice(path...)
+	if err != nil {
+		return err
+	}
+	src, err := other.MapSlice(path...)
+	if err != nil {
+		return fmt.Errorf("other: %s", err)
+	}
+	if len(dst) != len(src) {
+		return fmt.Errorf("array lengths differ: %d and other %d", len(dst), len(src))
+	}
+	for i := range dst {
+		mergeData(dst[i], src[i])
+	}
+	return nil
+}
+
+// MustZipMerge is a call to ZipMerge with a panic on none nil error
+func (j *Json) MustZipMerge(other *Json, path ...interface{}) {
+	panic.IfNotNil(j.ZipMerge(other, path...))
+}
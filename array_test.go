@@ -0,0 +1,505 @@
+package json
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_Stream(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`{"a":[1,2,3]}`)
+	a.Nil(err, "err is nil")
+
+	items, errs := obj.Stream(context.Background(), "a")
+	vals := []int{}
+	for item := range items {
+		vals = append(vals, item.MustInt())
+	}
+	a.Nil(<-errs, "err is nil")
+	a.Equal([]int{1, 2, 3}, vals, "vals is correct")
+}
+
+func Test_Stream_PathError(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`{"a":[1,2,3]}`)
+	a.Nil(err, "err is nil")
+
+	items, errs := obj.Stream(context.Background(), "b")
+	_, ok := <-items
+	a.False(ok, "items is closed")
+	err = <-errs
+	a.NotNil(err, "err is not nil")
+	a.Equal([]interface{}{"b"}, err.(*PathError).MissingPath, "error MissingPath is correct")
+}
+
+func Test_Stream_Cancelled(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`[1,2,3]`)
+	a.Nil(err, "err is nil")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	items, errs := obj.Stream(ctx)
+	<-items
+	cancel()
+	a.Equal(context.Canceled, <-errs, "err is context.Canceled")
+}
+
+func Test_UnionKeys(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`{"a":[{"c":1,"a":2},{},{"b":3,"a":4}]}`)
+	a.Nil(err, "err is nil")
+
+	keys, err := obj.UnionKeys("a")
+	a.Nil(err, "err is nil")
+	a.Equal([]string{"a", "b", "c"}, keys, "keys is correct")
+	obj.MustUnionKeys("a")
+}
+
+func Test_UnionKeys_NoneObjectElement(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`[{"a":1},2]`)
+	a.Nil(err, "err is nil")
+
+	keys, err := obj.UnionKeys()
+	a.NotNil(err, "err is not nil")
+	a.Equal("element 1: type assertion to map[string]interface{} failed", err.Error(), "error message is correct")
+	a.Nil(keys, "keys is nil")
+}
+
+func Test_SchemaDrift(t *testing.T) {
+	a := assert.New(t)
+
+	prev, err := FromString(`[{"id":1,"name":"a","tags":[]},{"id":2,"name":"b","note":null}]`)
+	a.Nil(err, "err is nil")
+	now, err := FromString(`[{"id":"1","tags":["x"],"email":"a@b"},{"id":"2","note":"hi","tags":[]}]`)
+	a.Nil(err, "err is nil")
+
+	drift, err := now.SchemaDrift(prev)
+	a.Nil(err, "err is nil")
+	a.Equal([]string{
+		`key "email" added (string)`,
+		`key "id" type changed from number to string`,
+		`key "name" removed (string)`,
+		`key "note" type changed from null to string`,
+	}, drift, "drift is correct")
+	a.Equal([]string{}, prev.MustSchemaDrift(prev), "no drift from itself")
+
+	_, err = now.SchemaDrift(MustFromString(`[1]`))
+	a.Equal("other: element 0: type assertion to map[string]interface{} failed", err.Error(), "error message is correct")
+	_, err = MustFromString(`{}`).SchemaDrift(prev)
+	a.Equal("type assertion to []interface{} failed", err.Error(), "error message is correct")
+}
+
+func Test_Rectangularize(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`{"a":[{"x":1},{"y":2},{"x":3,"z":null}]}`)
+	a.Nil(err, "err is nil")
+
+	err = obj.Rectangularize(nil, "a")
+	a.Nil(err, "err is nil")
+
+	str, err := obj.ToString()
+	a.Nil(err, "err is nil")
+	a.Equal(`{"a":[{"x":1,"y":null,"z":null},{"x":null,"y":2,"z":null},{"x":3,"y":null,"z":null}]}`, str, "str is correct value")
+
+	rows := MustFromString(`[{"a":1},{"b":{}},{"a":2}]`)
+	rows.MustRectangularize(map[string]interface{}{})
+	rows.MustSet(0, "b", "x", 1)
+	a.Equal(`[{"a":1,"b":{"x":1}},{"a":{},"b":{}},{"a":2,"b":{}}]`, rows.MustToString(), "container fill is not shared")
+}
+
+func Test_Rectangularize_NoneObjectElement(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`[{"x":1},true]`)
+	a.Nil(err, "err is nil")
+
+	err = obj.Rectangularize(0)
+	a.NotNil(err, "err is not nil")
+
+	str, err := obj.ToString()
+	a.Nil(err, "err is nil")
+	a.Equal(`[{"x":1},true]`, str, "str is unchanged")
+}
+
+func Test_IndexBy(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`{"a":[{"u":{"id":1},"v":"x"},{"u":{"id":"b"},"v":"y"},{"u":{"id":1},"v":"z"}]}`)
+	a.Nil(err, "err is nil")
+
+	index, err := obj.IndexBy("u.id", "a")
+	a.Nil(err, "err is nil")
+
+	str, err := index.ToString()
+	a.Nil(err, "err is nil")
+	a.Equal(`{"1":{"u":{"id":1},"v":"z"},"b":{"u":{"id":"b"},"v":"y"}}`, str, "str is correct value")
+
+	index.MustSet("b", "v", "changed")
+	a.Equal("y", obj.MustString("a", 1, "v"), "source is not modified")
+
+	arr, err := index.ToArrayFromKeyed()
+	a.Nil(err, "err is nil")
+	str, err = arr.ToString()
+	a.Nil(err, "err is nil")
+	a.Equal(`[{"u":{"id":1},"v":"z"},{"u":{"id":"b"},"v":"changed"}]`, str, "str is correct value")
+}
+
+func Test_IndexBy_MissingKey(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`[{"id":1},{"di":2}]`)
+	a.Nil(err, "err is nil")
+
+	index, err := obj.IndexBy("id")
+	a.Nil(index, "index is nil")
+	a.Equal("element 1: found: [] missing: [id]", err.Error(), "error message is correct")
+
+	obj, err = FromString(`[{"id":[]}]`)
+	a.Nil(err, "err is nil")
+
+	index, err = obj.IndexBy("id")
+	a.Nil(index, "index is nil")
+	a.Equal(`element 0: value at "id" is not a string, number or bool`, err.Error(), "error message is correct")
+}
+
+func Test_UpsertByKey(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`{"a":{"b":[{"u":{"id":1},"v":"x"},{"u":{"id":2},"v":"y"}]}}`)
+	a.Nil(err, "err is nil")
+
+	err = obj.UpsertByKey("u.id", MustFromString(`{"u":{"id":2.0},"v":"z"}`), "a", "b")
+	a.Nil(err, "err is nil")
+	obj.MustUpsertByKey("u.id", MustFromString(`{"u":{"id":3},"v":"w"}`), "a", "b")
+
+	str, err := obj.ToString()
+	a.Nil(err, "err is nil")
+	a.Equal(`{"a":{"b":[{"u":{"id":1},"v":"x"},{"u":{"id":2.0},"v":"z"},{"u":{"id":3},"v":"w"}]}}`, str, "str is correct value")
+
+	root, err := FromString(`[]`)
+	a.Nil(err, "err is nil")
+	root.MustUpsertByKey("id", MustFromString(`{"id":"a"}`))
+	a.Equal(`[{"id":"a"}]`, root.MustToString(), "root array is updated")
+}
+
+func Test_UpsertByKey_Error(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`[{"id":1}]`)
+	a.Nil(err, "err is nil")
+
+	err = obj.UpsertByKey("id", MustFromString(`{"di":1}`))
+	a.Equal("element: found: [] missing: [id]", err.Error(), "error message is correct")
+}
+
+func Test_RemoveWhere(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`{"a":[[1,2,3,4,5],[6]]}`)
+	a.Nil(err, "err is nil")
+
+	odd := func(js *Json) bool { return js.MustInt()%2 == 1 }
+	n, err := obj.RemoveWhere(odd, "a", 0)
+	a.Nil(err, "err is nil")
+	a.Equal(3, n, "n is correct")
+	a.Equal(0, obj.MustRemoveWhere(odd, "a", 1), "n is correct")
+
+	str, err := obj.ToString()
+	a.Nil(err, "err is nil")
+	a.Equal(`{"a":[[2,4],[6]]}`, str, "str is correct value")
+
+	root, err := FromString(`[1,2]`)
+	a.Nil(err, "err is nil")
+	a.Equal(1, root.MustRemoveWhere(odd), "n is correct")
+	a.Equal(`[2]`, root.MustToString(), "root array is updated")
+}
+
+func Test_RemoveWhere_NotSliceError(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`{"a":1}`)
+	a.Nil(err, "err is nil")
+
+	n, err := obj.RemoveWhere(func(*Json) bool { return true }, "a")
+	a.NotNil(err, "err is not nil")
+	a.Equal(0, n, "n is zero")
+}
+
+func Test_Dedupe(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`{"a":[1,"1",1.0,true,"x",null,true,10e-1,"x",null,{"a":1,"b":[2]},{"b":[2.0],"a":1},[1],[1.0],{}],"b":{}}`)
+	a.Nil(err, "err is nil")
+	obj.MustAppend("a", 1)
+
+	err = obj.Dedupe("a")
+	a.Nil(err, "err is nil")
+	a.Equal(`{"a":[1,"1",true,"x",null,{"a":1,"b":[2]},[1],{}],"b":{}}`, obj.MustToString(), "first occurrences are kept in order")
+
+	root := MustFromString(`[]`)
+	root.MustDedupe()
+	a.Equal(`[]`, root.MustToString(), "empty array is unchanged")
+
+	err = obj.Dedupe("b")
+	a.Equal("type assertion to []interface{} failed", err.Error(), "error message is correct")
+	obj.MustSet("a", 1, func() {})
+	err = obj.Dedupe("a")
+	a.Contains(err.Error(), "element 1: ", "error message names the element")
+	a.Panics(func() { obj.MustDedupe("c") }, "panics on error")
+}
+
+func Test_Partition(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`{"a":[{"ok":true},{"ok":false},{"ok":true,"n":1}]}`)
+	a.Nil(err, "err is nil")
+
+	valid := func(js *Json) bool { return js.BoolOrDefault(false, "ok") }
+	matched, rest, err := obj.Partition(valid, "a")
+	a.Nil(err, "err is nil")
+	a.Equal(`[{"ok":true},{"n":1,"ok":true}]`, matched.MustToString(), "matched is correct")
+	a.Equal(`[{"ok":false}]`, rest.MustToString(), "rest is correct")
+
+	matched.MustSet(0, "ok", "changed")
+	a.True(obj.MustBool("a", 0, "ok"), "source is not modified")
+
+	_, _, err = obj.Partition(valid, "b")
+	a.NotNil(err, "err is not nil")
+}
+
+func Test_Filter(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`{"a":[{"age":20},{"age":12},{"name":"x"},{"age":"30"}],"b":{}}`)
+	a.Nil(err, "err is nil")
+
+	adults, err := obj.Filter(func(i int, u *Json) bool { return u.IntOrDefault(0, "age") >= 18 }, "a")
+	a.Nil(err, "err is nil")
+	a.Equal(`[{"age":20},{"age":"30"}]`, adults.MustToString(), "matching elements are kept")
+
+	adults.MustSet(0, "age", 21)
+	a.Equal(20, obj.MustInt("a", 0, "age"), "source is not modified")
+
+	indices := []int{}
+	odd := obj.MustFilter(func(i int, u *Json) bool {
+		indices = append(indices, i)
+		return i%2 == 1
+	}, "a")
+	a.Equal([]int{0, 1, 2, 3}, indices, "indices are passed in order")
+	a.Equal(`[{"age":12},{"age":"30"}]`, odd.MustToString(), "odd is correct")
+	a.Equal(`[]`, obj.MustFilter(func(int, *Json) bool { return false }, "a").MustToString(), "no matches is an empty array")
+
+	_, err = obj.Filter(func(int, *Json) bool { return true }, "b")
+	a.Equal("type assertion to []interface{} failed", err.Error(), "error message is correct")
+	a.Panics(func() { obj.MustFilter(func(int, *Json) bool { return true }, "c") }, "panics on error")
+}
+
+func Test_MapEach(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`{"a":[{"name":"bob","age":"30"},{"name":"ann","age":4}],"b":{}}`)
+	a.Nil(err, "err is nil")
+
+	names, err := obj.MapEach(func(i int, u *Json) (interface{}, error) { return u.String("name") }, "a")
+	a.Nil(err, "err is nil")
+	a.Equal(`["bob","ann"]`, names.MustToString(), "names are correct")
+
+	ages := obj.MustMapEach(func(i int, u *Json) (interface{}, error) {
+		age, err := u.Int("age")
+		return map[string]interface{}{"i": i, "age": age}, err
+	}, "a")
+	a.Equal(`[{"age":30,"i":0},{"age":4,"i":1}]`, ages.MustToString(), "values are converted")
+	a.Equal(`[]`, MustFromString(`[]`).MustMapEach(func(int, *Json) (interface{}, error) { return nil, errors.New("x") }).MustToString(), "empty array maps to an empty array")
+
+	_, err = obj.MapEach(func(i int, u *Json) (interface{}, error) { return u.Bool("name") }, "a")
+	a.Equal("element 0: type assertion to bool failed", err.Error(), "error message is correct")
+	var te *TypeError
+	a.True(errors.As(err, &te), "fn error is wrapped")
+	errStop := errors.New("stop")
+	_, err = obj.MapEach(func(int, *Json) (interface{}, error) { return nil, errStop }, "a")
+	a.True(errors.Is(err, errStop), "fn error is wrapped")
+	_, err = obj.MapEach(func(int, *Json) (interface{}, error) { return nil, nil }, "b")
+	a.NotNil(err, "err is not nil")
+	a.Panics(func() { obj.MustMapEach(func(int, *Json) (interface{}, error) { return nil, nil }, "c") }, "panics on error")
+}
+
+func Test_Stats(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`{"a":[3,"-1",2.5,5.5],"b":[],"c":[1,true]}`)
+	a.Nil(err, "err is nil")
+
+	stats, err := obj.Stats("a")
+	a.Nil(err, "err is nil")
+	a.Equal(Stats{Count: 4, Sum: 10, Min: -1, Max: 5.5, Mean: 2.5}, stats, "stats is correct")
+	a.Equal(Stats{}, obj.MustStats("b"), "stats is zero")
+
+	stats, err = obj.Stats("c")
+	a.Equal("element 1: type assertion to number failed", err.Error(), "error message is correct")
+	a.Equal(Stats{}, stats, "stats is zero")
+}
+
+func Test_Pluck(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`{"a":[{"u":{"name":"bob"}},{"u":{}},{"u":{"name":"ann"}},1]}`)
+	a.Nil(err, "err is nil")
+
+	names, err := obj.Pluck("u.name", "a")
+	a.Nil(err, "err is nil")
+	a.Equal(`["bob",null,"ann",null]`, names.MustToString(), "names is correct")
+	a.Equal(`[{"name":"bob"},{},{"name":"ann"},null]`, obj.MustPluck("u", "a").MustToString(), "us is correct")
+
+	_, err = obj.Pluck("u", "b")
+	a.NotNil(err, "err is not nil")
+}
+
+func Test_Chunk(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`{"a":[1,2,3,4,5],"b":[]}`)
+	a.Nil(err, "err is nil")
+
+	chunks, err := obj.Chunk(2, "a")
+	a.Nil(err, "err is nil")
+	a.Equal(`[[1,2],[3,4],[5]]`, chunks.MustToString(), "chunks is correct")
+	a.Equal(`[[1,2,3,4,5]]`, obj.MustChunk(5, "a").MustToString(), "chunks is correct")
+	a.Equal(`[]`, obj.MustChunk(3, "b").MustToString(), "chunks is empty")
+
+	chunks.MustSet(0, 0, "changed")
+	a.Equal(1, obj.MustInt("a", 0), "source is not modified")
+
+	_, err = obj.Chunk(0, "a")
+	a.Equal("chunk size must be greater than 0", err.Error(), "error message is correct")
+}
+
+func Test_Reverse(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`[[1,2,3],"x",{"a":[]}]`)
+	a.Nil(err, "err is nil")
+
+	err = obj.Reverse(0)
+	a.Nil(err, "err is nil")
+	obj.MustReverse()
+	a.Equal(`[{"a":[]},"x",[3,2,1]]`, obj.MustToString(), "root and nested arrays are reversed")
+	obj.MustReverse(0, "a")
+	a.Equal(`[{"a":[]},"x",[3,2,1]]`, obj.MustToString(), "empty array is unchanged")
+
+	err = obj.Reverse(1)
+	a.Equal("type assertion to []interface{} failed", err.Error(), "error message is correct")
+	err = obj.Reverse(3)
+	a.Equal([]interface{}{3}, err.(*PathError).MissingPath, "error MissingPath is correct")
+	a.Panics(func() { obj.MustReverse(0) }, "panics on error")
+}
+
+func Test_ReverseSlice(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`{"a":[1,2,3,4],"b":[],"c":{}}`)
+	a.Nil(err, "err is nil")
+
+	err = obj.ReverseSlice("a")
+	a.Nil(err, "err is nil")
+	obj.MustReverseSlice("b")
+	a.Equal(`{"a":[4,3,2,1],"b":[],"c":{}}`, obj.MustToString(), "str is correct value")
+
+	err = obj.ReverseSlice("c")
+	a.Equal("type assertion to []interface{} failed", err.Error(), "error message is correct")
+	err = obj.ReverseSlice("d")
+	a.Equal([]interface{}{"d"}, err.(*PathError).MissingPath, "error MissingPath is correct")
+}
+
+func Test_SortSlice(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`{"a":[{"r":3,"n":"c"},{"r":1,"n":"a"},{"r":2,"n":"b"},{"r":1,"n":"d"}],"c":{}}`)
+	a.Nil(err, "err is nil")
+
+	err = obj.SortSlice(func(x, y *Json) bool { return x.MustInt("r") < y.MustInt("r") }, "a")
+	a.Nil(err, "err is nil")
+	a.Equal(`["a","d","b","c"]`, obj.MustPluck("n", "a").MustToString(), "sort is stable")
+	obj.MustSortSlice(func(x, y *Json) bool { return x.MustString("n") > y.MustString("n") }, "a")
+	a.Equal(`["d","c","b","a"]`, obj.MustPluck("n", "a").MustToString(), "sort is in place")
+
+	err = obj.SortSlice(func(x, y *Json) bool { return false }, "c")
+	a.Equal("type assertion to []interface{} failed", err.Error(), "error message is correct")
+	a.Panics(func() { obj.MustSortSlice(func(x, y *Json) bool { return false }, "d") }, "panics on error")
+}
+
+func Test_SortSliceByKey(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`{"a":[{"u":{"v":10}},{"u":{"v":"b"}},{"u":{"v":9.5}},{},{"u":{"v":"a"}},{"u":{"v":true}},{"u":{"v":2}}],"c":"x"}`)
+	a.Nil(err, "err is nil")
+
+	err = obj.SortSliceByKey("u.v", true, "a")
+	a.Nil(err, "err is nil")
+	a.Equal(`[null,true,2,9.5,10,"a","b"]`, obj.MustPluck("u.v", "a").MustToString(), "ascending order is correct")
+	obj.MustSortSliceByKey("u.v", false, "a")
+	a.Equal(`["b","a",10,9.5,2,true,null]`, obj.MustPluck("u.v", "a").MustToString(), "descending order is correct")
+
+	ties := MustFromString(`[{"k":1,"i":0},{"k":0,"i":1},{"k":1,"i":2}]`)
+	ties.MustSortSliceByKey("k", false)
+	a.Equal(`[0,2,1]`, ties.MustPluck("i").MustToString(), "descending sort is stable")
+
+	err = obj.SortSliceByKey("k", true, "c")
+	a.NotNil(err, "err is not nil")
+	a.Panics(func() { obj.MustSortSliceByKey("k", true, "d") }, "panics on error")
+}
+
+func Test_FlattenArray(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`{"a":[[1,2],3,[[4,[5]]],[]],"b":[[1,[2]],[[3]]],"c":{}}`)
+	a.Nil(err, "err is nil")
+
+	err = obj.FlattenArray("a")
+	a.Nil(err, "err is nil")
+	obj.MustFlattenArrayDeep("b")
+	a.Equal(`{"a":[1,2,3,[4,[5]]],"b":[1,2,3],"c":{}}`, obj.MustToString(), "str is correct value")
+
+	root := MustFromString(`[[1],[2,3]]`)
+	root.MustFlattenArray()
+	a.Equal(`[1,2,3]`, root.MustToString(), "root array is flattened")
+
+	err = obj.FlattenArray("c")
+	a.Equal("type assertion to []interface{} failed", err.Error(), "error message is correct")
+	err = obj.FlattenArrayDeep("d")
+	a.Equal([]interface{}{"d"}, err.(*PathError).MissingPath, "error MissingPath is correct")
+}
+
+func Test_ZipMerge(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`{"a":[{"id":1,"m":{"x":1}},{"id":2}]}`)
+	a.Nil(err, "err is nil")
+	other, err := FromString(`{"a":[{"score":5,"m":{"y":2}},{"score":7,"id":3}]}`)
+	a.Nil(err, "err is nil")
+
+	err = obj.ZipMerge(other, "a")
+	a.Nil(err, "err is nil")
+	a.Equal(`{"a":[{"id":1,"m":{"x":1,"y":2},"score":5},{"id":3,"score":7}]}`, obj.MustToString(), "str is correct value")
+
+	other.MustSet("a", 0, "m", "y", "changed")
+	a.Equal(2, obj.MustInt("a", 0, "m", "y"), "merged values are copies")
+}
+
+func Test_ZipMerge_LengthMismatch(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`[{},{}]`)
+	a.Nil(err, "err is nil")
+
+	err = obj.ZipMerge(MustFromString(`[{}]`))
+	a.Equal("array lengths differ: 2 and other 1", err.Error(), "error message is correct")
+}
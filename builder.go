@@ -0,0 +1,209 @@
+package json
+
+import (
+	"fmt"
+	"github.com/0xor1/panic"
+)
+
+// Builder accumulates Set and Append operations to be applied in order to a fresh
+// document by Build
+//		js, err := json.Object().
+//			Set("user", "name", "bob").
+//			Set("tags", []interface{}{}).
+//			Append("tags", "admin").
+//			Build()
+type Builder struct {
+	root func() interface{}
+	ops  []func(*Json) error
+	err  error
+}
+
+// Object returns a new `Builder` whose documents start as an empty object
+func Object() *Builder {
+	return &Builder{root: func() interface{} { return map[string]interface{}{} }}
+}
+
+// Array returns a new `Builder` whose documents start as an empty array
+func Array() *Builder {
+	return &Builder{root: func() interface{} { return []interface{}{} }}
+}
+
+// Set records a call to Set with `pathPartsThenValue`
+func (b *Builder) Set(pathPartsThenValue ...interface{}) *Builder {
+	return b.op("Set", pathPartsThenValue, (*Json).Set)
+}
+
+// Append records an append of the last value in `pathPartsThenValue` to the array
+// at the path given by the preceding values
+func (b *Builder) Append(pathPartsThenValue ...interface{}) *Builder {
+	return b.op("Append", pathPartsThenValue, (*Json).Append)
+}
+
+// op records `fn` to be called with a copy of `pathPartsThenValue` whose value is
+// deep copied on each Build, so documents never share data with each other or with
+// the caller
+func (b *Builder) op(name string, pathPartsThenValue []interface{}, fn func(*Json, ...interface{}) error) *Builder {
+	if b.err == nil && len(pathPartsThenValue) == 0 {
+		b.err = fmt.Errorf("builder op %d %s: no value supplied", len(b.ops), name)
+	}
+	i := len(b.ops)
+	b.ops = append(b.ops, func(j *Json) error {
+		args := append([]interface{}{}, pathPartsThenValue...)
+		if n := len(args); n > 0 {
+			args[n-1] = cloneData(args[n-1])
+		}
+		if err := fn(j, args...); err != nil {
+			return fmt.Errorf("builder op %d %s: %s", i, name, err)
+		}
+		return nil
+	})
+	return b
+}
+
+// Build applies the recorded operations to a fresh document, returning the first
+// error encountered
+func (b *Builder) Build() (*Json, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	j := &Json{data: b.root()}
+	for _, op := range b.ops {
+		if err := op(j); err != nil {
+			return nil, err
+		}
+	}
+	return j, nil
+}
+
+// MustBuild is a call to Build with a panic on none nil error
+func (b *Builder) MustBuild() *Json {
+	js, err := b.Build()
+	panic.IfNotNil(err)
+	return js
+}
+
+// BatchWriter accumulates Set and Append operations against an existing document to
+// be applied in order by Commit, which caches the objects resolved along each path so
+// that operations sharing path prefixes do not each navigate from the root
+//		err := js.Batch().
+//			Set("server", "http", "port", 80).
+//			Set("server", "http", "host", "localhost").
+//			Append("server", "tags", "web").
+//			Commit()
+type BatchWriter struct {
+	j   *Json
+	ops []batchOp
+}
+
+type batchOp struct {
+	append bool
+	empty  bool
+	path   []interface{}
+	val    interface{}
+}
+
+// Batch returns a new `BatchWriter` for `j`
+func (j *Json) Batch() *BatchWriter {
+	return &BatchWriter{j: j}
+}
+
+// Set records a call to Set with `pathPartsThenValue`
+func (b *BatchWriter) Set(pathPartsThenValue ...interface{}) *BatchWriter {
+	return b.op(false, pathPartsThenValue)
+}
+
+// Append records an append of the last value in `pathPartsThenValue` to the array
+// at the path given by the preceding values
+func (b *BatchWriter) Append(pathPartsThenValue ...interface{}) *BatchWriter {
+	return b.op(true, pathPartsThenValue)
+}
+
+func (b *BatchWriter) op(isAppend bool, pathPartsThenValue []interface{}) *BatchWriter {
+	op := batchOp{append: isAppend, empty: len(pathPartsThenValue) == 0}
+	if !op.empty {
+		n := len(pathPartsThenValue)
+		op.path, op.val = pathPartsThenValue[:n-1], pathPartsThenValue[n-1]
+	}
+	b.ops = append(b.ops, op)
+	return b
+}
+
+// Commit applies the recorded operations in order, stopping at and returning the
+// first error encountered, in which case the operations before it remain applied
+func (b *BatchWriter) Commit() error {
+	c := &batchChain{}
+	for i, op := range b.ops {
+		if op.empty {
+			return fmt.Errorf("batch op %d: no value supplied", i)
+		}
+		val := op.val
+		if op.append {
+			arr, err := b.j.appended(op.path, op.val)
+			if err != nil {
+				return err
+			}
+			val = arr
+		}
+		if !c.set(b.j, op.path, val) {
+			c.reset()
+			if err := b.j.setAt(op.path, val); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// batchChain holds the objects resolved along the most recently set path, maps[i]
+// being the object at path[:i]
+type batchChain struct {
+	path []string
+	maps []map[string]interface{}
+}
+
+func (c *batchChain) reset() {
+	c.path, c.maps = c.path[:0], c.maps[:0]
+}
+
+// set assigns `val` at `path` reusing the objects resolved by the previous call for
+// any shared prefix, reporting false without making any changes if the path can not
+// be resolved through objects alone
+func (c *batchChain) set(j *Json, path []interface{}, val interface{}) bool {
+	if len(path) == 0 {
+		return false
+	}
+	for _, p := range path {
+		if _, ok := p.(string); !ok {
+			return false
+		}
+	}
+	if len(c.maps) == 0 {
+		root, ok := j.data.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		c.maps = append(c.maps, root)
+	}
+	k := 0
+	for k < len(c.path) && k < len(path)-1 && c.path[k] == path[k].(string) {
+		k++
+	}
+	c.path, c.maps = c.path[:k], c.maps[:k+1]
+	cur := c.maps[k]
+	for i := k; i < len(path)-1; i++ {
+		key := path[i].(string)
+		child, exists := cur[key]
+		if !exists {
+			child = map[string]interface{}{}
+			cur[key] = child
+		}
+		m, ok := child.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		c.path, c.maps = append(c.path, key), append(c.maps, m)
+		cur = m
+	}
+	cur[path[len(path)-1].(string)] = val
+	return true
+}
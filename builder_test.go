@@ -0,0 +1,100 @@
+package json
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_Builder(t *testing.T) {
+	a := assert.New(t)
+
+	b := Object().
+		Set("user", "name", "bob").
+		Set("tags", []interface{}{}).
+		Append("tags", "admin").
+		Append("tags", "dev")
+	obj, err := b.Build()
+	a.Nil(err, "err is nil")
+
+	str, err := obj.ToString()
+	a.Nil(err, "err is nil")
+	a.Equal(`{"tags":["admin","dev"],"user":{"name":"bob"}}`, str, "str is correct value")
+
+	obj.MustSet("user", "name", "changed")
+	a.Equal("bob", b.MustBuild().MustString("user", "name"), "each build is a fresh document")
+
+	b = Object().Set("u", map[string]interface{}{}).Set("u", "n", 1)
+	j1, j2 := b.MustBuild(), b.MustBuild()
+	j1.MustSet("u", "x", true)
+	str, err = j2.ToString()
+	a.Nil(err, "err is nil")
+	a.Equal(`{"u":{"n":1}}`, str, "builds do not share values")
+
+	str, err = Array().Append(1).Append(2).MustBuild().ToString()
+	a.Nil(err, "err is nil")
+	a.Equal(`[1,2]`, str, "str is correct value")
+}
+
+func Test_Builder_Error(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := Object().Set("a", 1).Append("a", 2).Build()
+	a.Nil(obj, "obj is nil")
+	a.Equal("builder op 1 Append: found: [a] missing: []", err.Error(), "error message is correct")
+
+	obj, err = Object().Set("a", 1).Set().Build()
+	a.Nil(obj, "obj is nil")
+	a.Equal("builder op 1 Set: no value supplied", err.Error(), "error message is correct")
+}
+
+func Test_Batch(t *testing.T) {
+	a := assert.New(t)
+
+	js := MustFromString(`{"server":{"tags":["a"],"name":"x"},"list":[{"id":1}]}`)
+	err := js.Batch().
+		Set("server", "http", "port", 80).
+		Set("server", "http", "host", "localhost").
+		Append("server", "tags", "web").
+		Set("list", 0, "id", 2).
+		Set("server", "http", "port", 81).
+		Commit()
+	a.Nil(err, "err is nil")
+	str, err := js.ToString()
+	a.Nil(err, "err is nil")
+	a.Equal(`{"list":[{"id":2}],"server":{"http":{"host":"localhost","port":81},"name":"x","tags":["a","web"]}}`, str, "str is correct value")
+
+	err = js.Batch().
+		Set("server", "http", map[string]interface{}{}).
+		Set("server", "http", "port", 82).
+		Commit()
+	a.Nil(err, "err is nil")
+	a.Equal(`{"port":82}`, js.MustGet("server", "http").MustToString(), "replaced containers are not served from the cache")
+
+	err = js.Batch().Set("server", "name", "y").Set("server", "name", "z", 1).Commit()
+	a.Equal(`found: [server name] missing: [z]`, err.Error(), "error message is correct")
+	a.Equal("y", js.MustString("server", "name"), "ops before the failure remain applied")
+
+	err = js.Batch().Set().Commit()
+	a.Equal("batch op 0: no value supplied", err.Error(), "error message is correct")
+}
+
+func BenchmarkSet(b *testing.B) {
+	for n := 0; n < b.N; n++ {
+		js := MustNew()
+		for i := 0; i < 100; i++ {
+			js.Set("a", "b", "c", "d", fmt.Sprintf("k%d", i), i)
+		}
+	}
+}
+
+func BenchmarkBatch(b *testing.B) {
+	for n := 0; n < b.N; n++ {
+		js := MustNew()
+		batch := js.Batch()
+		for i := 0; i < 100; i++ {
+			batch.Set("a", "b", "c", "d", fmt.Sprintf("k%d", i), i)
+		}
+		batch.Commit()
+	}
+}
@@ -0,0 +1,255 @@
+package json
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/0xor1/panic"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ToCanonicalBytes returns the document encoded with sorted object keys, no
+// insignificant whitespace and every number normalized, so documents differing only
+// in key order, whitespace or number formatting encode to identical bytes. Values are
+// first marshaled as by ToBytes so `time.Time`s, structs and other Go values set into
+// the document are canonicalized by their encodings, a `time.Time` therefore encodes
+// in its own zone rather than as any string Equal would match it to. Numbers are
+// written as by ECMAScript's Number.prototype.toString, without its loss of
+// precision, so 1, 1.0 and 10e-1 all encode as 1 and 1e21 encodes as 1e+21.
+func (j *Json) ToCanonicalBytes() ([]byte, error) {
+	b, err := json.Marshal(j.data)
+	if err != nil {
+		return nil, err
+	}
+	tmp, err := FromBytes(b)
+	if err != nil {
+		return nil, err
+	}
+	buf := &bytes.Buffer{}
+	err = encodeCanonical(buf, tmp.data)
+	return buf.Bytes(), err
+}
+
+// MustToCanonicalBytes is a call to ToCanonicalBytes with a panic on none nil error
+func (j *Json) MustToCanonicalBytes() []byte {
+	b, err := j.ToCanonicalBytes()
+	panic.IfNotNil(err)
+	return b
+}
+
+// encodeCanonical writes decoded `data` to `buf` as described by ToCanonicalBytes
+func encodeCanonical(buf *bytes.Buffer, data interface{}) error {
+	switch v := data.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		buf.WriteByte('{')
+		for i, k := range keys {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			b, _ := json.Marshal(k)
+			buf.Write(b)
+			buf.WriteByte(':')
+			if err := encodeCanonical(buf, v[k]); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+		return nil
+	case []interface{}:
+		buf.WriteByte('[')
+		for i, e := range v {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := encodeCanonical(buf, e); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+		return nil
+	case json.Number:
+		str, err := canonicalNumber(v)
+		buf.WriteString(str)
+		return err
+	}
+	b, err := json.Marshal(data)
+	buf.Write(b)
+	return err
+}
+
+// canonicalNumber formats `n` as described by ToCanonicalBytes, working on its
+// decimal digits rather than its value so that large exponents are cheap
+func canonicalNumber(n json.Number) (string, error) {
+	str := string(n)
+	neg := strings.HasPrefix(str, "-")
+	str = strings.TrimPrefix(str, "-")
+	exp := 0
+	if i := strings.IndexAny(str, "eE"); i >= 0 {
+		e, err := strconv.Atoi(str[i+1:])
+		if err != nil {
+			return "", fmt.Errorf("invalid number %s", n)
+		}
+		exp, str = e, str[:i]
+	}
+	if i := strings.IndexByte(str, '.'); i >= 0 {
+		exp -= len(str) - i - 1
+		str = str[:i] + str[i+1:]
+	}
+	if !isDigits(str) {
+		return "", fmt.Errorf("invalid number %s", n)
+	}
+	digits := strings.TrimLeft(str, "0")
+	if digits == "" {
+		return "0", nil
+	}
+	trimmed := strings.TrimRight(digits, "0")
+	exp += len(digits) - len(trimmed)
+	digits = trimmed
+	// point is the position of the decimal point relative to the start of digits
+	k, point := len(digits), len(digits)+exp
+	var res string
+	switch {
+	case k <= point && point <= 21:
+		res = digits + strings.Repeat("0", point-k)
+	case 0 < point && point <= 21:
+		res = digits[:point] + "." + digits[point:]
+	case -6 < point && point <= 0:
+		res = "0." + strings.Repeat("0", -point) + digits
+	default:
+		res = digits[:1]
+		if k > 1 {
+			res += "." + digits[1:]
+		}
+		if point-1 >= 0 {
+			res += "e+" + strconv.Itoa(point-1)
+		} else {
+			res += "e-" + strconv.Itoa(1-point)
+		}
+	}
+	if neg {
+		res = "-" + res
+	}
+	return res, nil
+}
+
+// CanonicalUnordered returns the document encoded with sorted object keys and with
+// every array sorted, so documents holding the same sets of values in any order
+// encode identically, as by ToCanonicalBytes. Elements are ordered first by kind,
+// null, bool, number, string, array then object, then scalars by value, numbers
+// numerically, and objects by the values of `sortKeys` in turn, an object missing a
+// key sorting before one with it. All remaining ties are broken by comparing the
+// elements' canonical encodings so the result depends only on the set of elements,
+// not their original order or formatting.
+//		[{"id":2},{"id":1,"x":[3,1]}] => [{"id":1,"x":[1,3]},{"id":2}] with sortKeys "id"
+func (j *Json) CanonicalUnordered(sortKeys ...string) ([]byte, error) {
+	data, err := transform(cloneData(j.data), nil, func(path []interface{}, v interface{}) (interface{}, error) {
+		arr, ok := v.([]interface{})
+		if !ok {
+			return v, nil
+		}
+		return sortUnordered(arr, sortKeys)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return (&Json{data: data}).ToCanonicalBytes()
+}
+
+// MustCanonicalUnordered is a call to CanonicalUnordered with a panic on none nil error
+func (j *Json) MustCanonicalUnordered(sortKeys ...string) []byte {
+	b, err := j.CanonicalUnordered(sortKeys...)
+	panic.IfNotNil(err)
+	return b
+}
+
+// sortUnordered sorts `arr` in place as described by CanonicalUnordered
+func sortUnordered(arr []interface{}, sortKeys []string) ([]interface{}, error) {
+	type elem struct {
+		v    interface{}
+		kind Kind
+		enc  []byte
+	}
+	elems := make([]elem, len(arr))
+	for i, v := range arr {
+		kind, err := kindOf(v)
+		if err != nil {
+			return nil, err
+		}
+		enc, err := (&Json{data: v}).ToCanonicalBytes()
+		if err != nil {
+			return nil, err
+		}
+		elems[i] = elem{v, kind, enc}
+	}
+	sort.SliceStable(elems, func(x, y int) bool {
+		a, b := elems[x], elems[y]
+		if a.kind != b.kind {
+			return a.kind < b.kind
+		}
+		if c := compareUnordered(a.v, b.v, sortKeys); c != 0 {
+			return c < 0
+		}
+		return bytes.Compare(a.enc, b.enc) < 0
+	})
+	for i := range elems {
+		arr[i] = elems[i].v
+	}
+	return arr, nil
+}
+
+// compareUnordered compares two values of the same kind, returning 0 for values it
+// can not distinguish without their encodings
+func compareUnordered(a, b interface{}, sortKeys []string) int {
+	switch av := a.(type) {
+	case bool:
+		bv := b.(bool)
+		if av == bv {
+			return 0
+		} else if !av {
+			return -1
+		}
+		return 1
+	case string:
+		return strings.Compare(av, b.(string))
+	case map[string]interface{}:
+		bv := b.(map[string]interface{})
+		for _, k := range sortKeys {
+			ae, aOk := av[k]
+			be, bOk := bv[k]
+			if aOk != bOk {
+				if !aOk {
+					return -1
+				}
+				return 1
+			}
+			if !aOk {
+				continue
+			}
+			ak, _ := kindOf(ae)
+			bk, _ := kindOf(be)
+			if ak != bk {
+				if ak < bk {
+					return -1
+				}
+				return 1
+			}
+			if c := compareUnordered(ae, be, nil); c != 0 {
+				return c
+			}
+		}
+		return 0
+	}
+	if ar, ok := numberRat(a); ok {
+		if br, ok := numberRat(b); ok {
+			return ar.Cmp(br)
+		}
+	}
+	return 0
+}
@@ -0,0 +1,70 @@
+package json
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_CanonicalUnordered(t *testing.T) {
+	a := assert.New(t)
+
+	obj1, err := FromString(`{"b":[{"id":2,"v":"x"},{"id":10,"tags":["z","a"]},{"v":"y"}],"a":[3,"b",1.5,true,null,"a",[2,1],{}]}`)
+	a.Nil(err, "err is nil")
+	obj2, err := FromString(`{"a":[{},"a",[1,2],null,true,1.5,3,"b"],"b":[{"v":"y"},{"tags":["a","z"],"id":10},{"v":"x","id":2}]}`)
+	a.Nil(err, "err is nil")
+
+	b, err := obj1.CanonicalUnordered("id")
+	a.Nil(err, "err is nil")
+	a.Equal(`{"a":[null,true,1.5,3,"a","b",[1,2],{}],"b":[{"v":"y"},{"id":2,"v":"x"},{"id":10,"tags":["a","z"]}]}`, string(b), "canonical form is correct")
+	a.Equal(string(b), string(obj2.MustCanonicalUnordered("id")), "same sets in any order are equal")
+	a.Equal(`{"a":[3,"b",1.5,true,null,"a",[2,1],{}],"b":[{"id":2,"v":"x"},{"id":10,"tags":["z","a"]},{"v":"y"}]}`, obj1.MustToString(), "original is not modified")
+
+	ties := MustFromString(`[{"k":1,"v":"b"},{"k":1,"v":"a"}]`)
+	a.Equal(`[{"k":1,"v":"a"},{"k":1,"v":"b"}]`, string(ties.MustCanonicalUnordered("k")), "ties are broken by encoding")
+
+	formatted := MustFromString(`[{"a":10e-1,"b":1},{"a":1,"b":2}]`)
+	plain := MustFromString(`[{"a":1,"b":2},{"a":1,"b":1}]`)
+	a.Equal(`[{"a":1,"b":1},{"a":1,"b":2}]`, string(formatted.MustCanonicalUnordered()), "ties are broken by canonical encoding")
+	a.Equal(string(formatted.MustCanonicalUnordered()), string(plain.MustCanonicalUnordered()), "number formatting does not affect order")
+}
+
+func Test_ToCanonicalBytes(t *testing.T) {
+	a := assert.New(t)
+
+	obj1 := MustFromString(`{ "b" : [1.0, 10e-1, 0.10, -0], "a":{"z":"<x>","y":1E21} }`)
+	obj2 := MustFromString(`{"a":{"y":1000000000000000000000,"z":"<x>"},"b":[1,1,0.1,0]}`)
+	a.True(obj1.Equal(obj2), "documents are equal")
+
+	b, err := obj1.ToCanonicalBytes()
+	a.Nil(err, "err is nil")
+	a.Equal(`{"a":{"y":1e+21,"z":"\u003cx\u003e"},"b":[1,1,0.1,0]}`, string(b), "canonical form is correct")
+	a.Equal(b, obj2.MustToCanonicalBytes(), "equal documents have identical bytes")
+
+	obj3 := MustNew().MustSet("a", float64(1.5)).MustSet("b", int8(-3)).MustSet("c", "12.50").MustSet("d", struct {
+		X uint `json:"x"`
+	}{7})
+	a.Equal(`{"a":1.5,"b":-3,"c":"12.50","d":{"x":7}}`, string(obj3.MustToCanonicalBytes()), "go values are canonicalized")
+
+	for in, out := range map[string]string{
+		"0":                       "0",
+		"-0.0e5":                  "0",
+		"123":                     "123",
+		"-1.2300":                 "-1.23",
+		"0.000001":                "0.000001",
+		"0.0000001":               "1e-7",
+		"123456789012345678901":   "123456789012345678901",
+		"1234567890123456789012":  "1.234567890123456789012e+21",
+		"12345678901234567890123": "1.2345678901234567890123e+22",
+		"1.5e-10":                 "1.5e-10",
+		"25e1000":                 "2.5e+1001",
+	} {
+		b, err := MustFromString(in).ToCanonicalBytes()
+		a.Nil(err, "err is nil")
+		a.Equal(out, string(b), "number %s is canonical", in)
+	}
+
+	obj3.MustSet("e", func() {})
+	_, err = obj3.ToCanonicalBytes()
+	a.NotNil(err, "err is not nil")
+	a.Panics(func() { obj3.MustToCanonicalBytes() }, "panics on error")
+}
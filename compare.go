@@ -0,0 +1,156 @@
+package json
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"sort"
+	"time"
+)
+
+const (
+	ChangeAdd    = "add"
+	ChangeRemove = "remove"
+	ChangeModify = "modify"
+)
+
+// Change describes a single difference between two documents, Old is nil for
+// ChangeAdd and New is nil for ChangeRemove
+type Change struct {
+	Path []interface{}
+	Op   string
+	Old  *Json
+	New  *Json
+}
+
+// Changes returns the list of changes that turn `old` into `j`, objects are compared
+// key by key and arrays index by index, numbers are compared by value regardless
+// of their representation
+func (j *Json) Changes(old *Json) []Change {
+	changes := []Change{}
+	compareData(old.data, j.data, nil, func(path []interface{}, a, b interface{}, op string) error {
+		c := Change{Path: append([]interface{}{}, path...), Op: op}
+		if op != ChangeAdd {
+			c.Old = &Json{data: a}
+		}
+		if op != ChangeRemove {
+			c.New = &Json{data: b}
+		}
+		changes = append(changes, c)
+		return nil
+	})
+	return changes
+}
+
+// Equal reports whether `j` and `other` hold structurally equal documents, objects
+// are compared regardless of key order, arrays index by index, numbers by value
+// regardless of their representation, and a `time.Time` is equal to a string that
+// parses, as by Time, to the same instant
+func (j *Json) Equal(other *Json) bool {
+	a, err := j.Get()
+	if err != nil {
+		return false
+	}
+	b, err := other.Get()
+	if err != nil {
+		return false
+	}
+	return compareData(a.data, b.data, nil, func(path []interface{}, av, bv interface{}, op string) error {
+		_, aTime := av.(time.Time)
+		_, bTime := bv.(time.Time)
+		if op == ChangeModify && (aTime || bTime) {
+			at, aErr := j.wrap(av).Time()
+			bt, bErr := other.wrap(bv).Time()
+			if aErr == nil && bErr == nil && at.Equal(bt) {
+				return nil
+			}
+		}
+		return fmt.Errorf("not equal")
+	}) == nil
+}
+
+// compareData walks `a` and `b` in parallel, descending into objects and arrays present
+// on both sides, and calls `fn` for every path at which they differ with the op that
+// turns `a` into `b`. Traversal stops at the first error returned from `fn`.
+func compareData(a, b interface{}, path []interface{}, fn func(path []interface{}, a, b interface{}, op string) error) error {
+	switch av := a.(type) {
+	case map[string]interface{}:
+		if bv, ok := b.(map[string]interface{}); ok {
+			keys := make([]string, 0, len(av)+len(bv))
+			for k := range av {
+				keys = append(keys, k)
+			}
+			for k := range bv {
+				if _, exists := av[k]; !exists {
+					keys = append(keys, k)
+				}
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				ae, aExists := av[k]
+				be, bExists := bv[k]
+				var err error
+				if !aExists {
+					err = fn(append(path, k), nil, be, ChangeAdd)
+				} else if !bExists {
+					err = fn(append(path, k), ae, nil, ChangeRemove)
+				} else {
+					err = compareData(ae, be, append(path, k), fn)
+				}
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	case []interface{}:
+		if bv, ok := b.([]interface{}); ok {
+			for i := 0; i < len(av) || i < len(bv); i++ {
+				var err error
+				if i >= len(av) {
+					err = fn(append(path, i), nil, bv[i], ChangeAdd)
+				} else if i >= len(bv) {
+					err = fn(append(path, i), av[i], nil, ChangeRemove)
+				} else {
+					err = compareData(av[i], bv[i], append(path, i), fn)
+				}
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}
+	if !equalScalar(a, b) {
+		return fn(path, a, b, ChangeModify)
+	}
+	return nil
+}
+
+// equalScalar compares two none container values, numbers are compared by value
+func equalScalar(a, b interface{}) bool {
+	if ar, ok := numberRat(a); ok {
+		if br, ok := numberRat(b); ok {
+			return ar.Cmp(br) == 0
+		}
+		return false
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// numberRat returns the exact value of a numeric `v` as a `*big.Rat`
+func numberRat(v interface{}) (*big.Rat, bool) {
+	switch n := v.(type) {
+	case json.Number:
+		return new(big.Rat).SetString(string(n))
+	case float32, float64:
+		r := new(big.Rat).SetFloat64(reflect.ValueOf(n).Float())
+		return r, r != nil
+	case int, int8, int16, int32, int64:
+		return new(big.Rat).SetInt64(reflect.ValueOf(n).Int()), true
+	case uint, uint8, uint16, uint32, uint64:
+		return new(big.Rat).SetInt(new(big.Int).SetUint64(reflect.ValueOf(n).Uint())), true
+	}
+	return nil, false
+}
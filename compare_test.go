@@ -0,0 +1,82 @@
+package json
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func Test_Changes(t *testing.T) {
+	a := assert.New(t)
+
+	old, err := FromString(`{"a":1,"b":{"c":[1,2,3],"d":"x"},"e":true}`)
+	a.Nil(err, "err is nil")
+	obj, err := FromString(`{"a":1.0,"b":{"c":[1,5],"d":{"y":1}},"f":null}`)
+	a.Nil(err, "err is nil")
+
+	changes := obj.Changes(old)
+	a.Equal(5, len(changes), "changes length is correct")
+
+	a.Equal([]interface{}{"b", "c", 1}, changes[0].Path, "change 0 path is correct")
+	a.Equal(ChangeModify, changes[0].Op, "change 0 op is correct")
+	a.Equal(2, changes[0].Old.MustInt(), "change 0 old is correct")
+	a.Equal(5, changes[0].New.MustInt(), "change 0 new is correct")
+
+	a.Equal([]interface{}{"b", "c", 2}, changes[1].Path, "change 1 path is correct")
+	a.Equal(ChangeRemove, changes[1].Op, "change 1 op is correct")
+	a.Equal(3, changes[1].Old.MustInt(), "change 1 old is correct")
+	a.Nil(changes[1].New, "change 1 new is nil")
+
+	a.Equal([]interface{}{"b", "d"}, changes[2].Path, "change 2 path is correct")
+	a.Equal(ChangeModify, changes[2].Op, "change 2 op is correct")
+	a.Equal("x", changes[2].Old.MustString(), "change 2 old is correct")
+	a.Equal(1, changes[2].New.MustInt("y"), "change 2 new is correct")
+
+	a.Equal([]interface{}{"e"}, changes[3].Path, "change 3 path is correct")
+	a.Equal(ChangeRemove, changes[3].Op, "change 3 op is correct")
+
+	a.Equal([]interface{}{"f"}, changes[4].Path, "change 4 path is correct")
+	a.Equal(ChangeAdd, changes[4].Op, "change 4 op is correct")
+	a.Nil(changes[4].Old, "change 4 old is nil")
+	a.Nil(changes[4].New.MustInterface(), "change 4 new is json null")
+}
+
+func Test_Changes_None(t *testing.T) {
+	a := assert.New(t)
+
+	old := FromInterface(map[string]interface{}{"a": 1, "b": []interface{}{uint8(2), 3.0}})
+	obj, err := FromString(`{"a":1,"b":[2,3]}`)
+	a.Nil(err, "err is nil")
+
+	a.Equal([]Change{}, obj.Changes(old), "changes is empty")
+}
+
+func Test_Equal(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`{"a":1,"b":[1.5,{"c":2}],"d":"x","e":null}`)
+	a.Nil(err, "err is nil")
+
+	other := FromInterface(map[string]interface{}{
+		"e": nil,
+		"d": "x",
+		"b": []interface{}{float32(1.5), map[string]interface{}{"c": int64(2)}},
+		"a": 1.0,
+	})
+	a.True(obj.Equal(other), "mixed number representations are equal")
+	a.True(other.Equal(obj), "equality is symmetric")
+	a.True(obj.Equal(obj.Clone()), "clone is equal")
+
+	a.False(obj.Equal(MustFromString(`{"a":1,"b":[{"c":2},1.5],"d":"x","e":null}`)), "arrays are positional")
+	a.False(obj.Equal(MustFromString(`{"a":1,"b":[1.5,{"c":2}],"d":"x"}`)), "missing null is not equal")
+	a.False(obj.Equal(MustFromString(`{"a":"1","b":[1.5,{"c":2}],"d":"x","e":null}`)), "numeric string is not a number")
+	a.False(obj.Equal(obj.Maybe("z")), "nothing is not equal")
+
+	now := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	withTime := FromInterface(map[string]interface{}{"t": now})
+	a.True(withTime.Equal(MustFromString(`{"t":"2020-01-02T03:04:05Z"}`)), "time equals its string form")
+	a.True(MustFromString(`{"t":"2020-01-02T04:04:05+01:00"}`).Equal(withTime), "time equals the same instant in another zone")
+	a.True(MustFromString(`{"t":"02/01/2020 03:04:05"}`).WithTimeLayout("02/01/2006 15:04:05").Equal(withTime), "time layout is used")
+	a.False(withTime.Equal(MustFromString(`{"t":"2020-01-02T03:04:06Z"}`)), "different time is not equal")
+	a.False(withTime.Equal(MustFromString(`{"t":"not a time"}`)), "unparseable string is not equal")
+}
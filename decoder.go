@@ -0,0 +1,60 @@
+package json
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// Decoder reads a stream of JSON values, such as newline delimited JSON or simply
+// concatenated values, decoding them one at a time
+type Decoder struct {
+	dec       *json.Decoder
+	useNumber bool
+}
+
+// DecodeOption configures how a `Decoder`, or FromReaderWithOptions, decodes values.
+// Options set fields on the `Decoder` rather than acting on a `json.Decoder` directly
+// because UseNumber can not be undone on a `json.Decoder`, so the default of decoding
+// numbers as `json.Number` is only applied once every option has been. There is no
+// DisallowUnknownFields option as it only affects decoding into structs and so has no
+// effect when decoding into the `interface{}` held by a `Json`.
+type DecodeOption func(*Decoder)
+
+// UseFloat64 decodes numbers as `float64`, as encoding/json does by default, rather
+// than as `json.Number`, trading precision for not having to convert them later
+func UseFloat64() DecodeOption {
+	return func(d *Decoder) {
+		d.useNumber = false
+	}
+}
+
+// NewDecoder returns a new `Decoder` reading from `r`, numbers are decoded as
+// `json.Number` as they are by FromReader unless UseFloat64 is passed
+//		dec := json.NewDecoder(r)
+//		for {
+//			js, err := dec.Next()
+//			if err == io.EOF {
+//				break
+//			}
+//			...
+//		}
+func NewDecoder(r io.Reader, opts ...DecodeOption) *Decoder {
+	d := &Decoder{dec: json.NewDecoder(r), useNumber: true}
+	for _, opt := range opts {
+		opt(d)
+	}
+	if d.useNumber {
+		d.dec.UseNumber()
+	}
+	return d
+}
+
+// Next decodes the next value in the stream, returning `io.EOF` once the stream is
+// exhausted
+func (d *Decoder) Next() (*Json, error) {
+	j := &Json{}
+	if err := d.dec.Decode(&j.data); err != nil {
+		return nil, err
+	}
+	return j, nil
+}
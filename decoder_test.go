@@ -0,0 +1,56 @@
+package json
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"strings"
+	"testing"
+)
+
+func Test_Decoder(t *testing.T) {
+	a := assert.New(t)
+
+	dec := NewDecoder(strings.NewReader("{\"a\":1}\n{\"a\":2}\n\n[3] \"x\"null\n"))
+	vals := []string{}
+	for {
+		js, err := dec.Next()
+		if err == io.EOF {
+			break
+		}
+		a.Nil(err, "err is nil")
+		vals = append(vals, js.MustToString())
+	}
+	a.Equal([]string{`{"a":1}`, `{"a":2}`, `[3]`, `"x"`, `null`}, vals, "vals are correct")
+
+	dec = NewDecoder(strings.NewReader(`{"a":12345678901234567890}`))
+	js, err := dec.Next()
+	a.Nil(err, "err is nil")
+	a.Equal(json.Number("12345678901234567890"), js.MustNumber("a"), "numbers are json.Number")
+	_, err = dec.Next()
+	a.Equal(io.EOF, err, "err is io.EOF")
+}
+
+func Test_Decoder_Error(t *testing.T) {
+	a := assert.New(t)
+
+	dec := NewDecoder(strings.NewReader("{\"a\":1}\n{\"a\":"))
+	js, err := dec.Next()
+	a.Nil(err, "err is nil")
+	a.Equal(int64(1), js.MustInt64("a"), "first value is decoded")
+	js, err = dec.Next()
+	a.Nil(js, "js is nil")
+	a.Equal(io.ErrUnexpectedEOF, err, "err is io.ErrUnexpectedEOF")
+}
+
+func Test_Decoder_UseFloat64(t *testing.T) {
+	a := assert.New(t)
+
+	dec := NewDecoder(strings.NewReader("1 2.5"), UseFloat64())
+	js, err := dec.Next()
+	a.Nil(err, "err is nil")
+	a.Equal(float64(1), js.MustInterface(), "number is float64")
+	js, err = dec.Next()
+	a.Nil(err, "err is nil")
+	a.Equal(2.5, js.MustInterface(), "number is float64")
+}
@@ -2,21 +2,34 @@ package json
 
 import (
 	"bytes"
+	"context"
+	"crypto/sha256"
+	"database/sql/driver"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/0xor1/panic"
 	"io"
 	"io/ioutil"
+	"math"
+	"math/big"
 	"os"
 	"path/filepath"
 	"reflect"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
+	"unicode/utf8"
 )
 
 type Json struct {
-	data interface{}
+	data       interface{}
+	timeLayout string
+	order      *keyOrder
+	nothing    bool
 }
 
 // New returns a pointer to a new, empty `Json` object
@@ -34,7 +47,23 @@ func MustNew() *Json {
 // FromInterface returns a pointer to a new `Json` object
 // after assigning `i` to its internal data
 func FromInterface(i interface{}) *Json {
-	return &Json{i}
+	return &Json{data: i}
+}
+
+// Clone returns a deep copy of `j`, objects and arrays are copied recursively and
+// all other values, such as `json.Number` and `time.Time`, are copied as is
+func (j *Json) Clone() *Json {
+	c := &Json{data: cloneData(j.data), timeLayout: j.timeLayout, nothing: j.nothing}
+	if j.order != nil {
+		c.order = j.order.cloned(j.data, c.data)
+	}
+	return c
+}
+
+// swap replaces the data of `j`, and its key order, with those of `tmp`, a Clone of
+// `j` that changes have been made to
+func (j *Json) swap(tmp *Json) {
+	j.data, j.order = tmp.data, tmp.order
 }
 
 // FromString returns a pointer to a new `Json` object
@@ -63,6 +92,95 @@ func MustFromBytes(b []byte) *Json {
 	return js
 }
 
+// FromFlatMap returns a pointer to a new `Json` object built from `m` by splitting
+// each key on `sep` into the path of nested objects at which its value is set
+//		{"db_host": "x", "db_port": "5432"}, "_" => {"db":{"host":"x","port":"5432"}}
+func FromFlatMap(m map[string]string, sep string) (*Json, error) {
+	return fromFlatMap(m, sep, func(s string) interface{} { return s })
+}
+
+// MustFromFlatMap is a call to FromFlatMap with a panic on none nil error
+func MustFromFlatMap(m map[string]string, sep string) *Json {
+	js, err := FromFlatMap(m, sep)
+	panic.IfNotNil(err)
+	return js
+}
+
+// FromFlatMapTyped is like FromFlatMap but infers the types of the values, "true" and
+// "false" become bools, numbers become `json.Number`, valid JSON objects, arrays and
+// null are decoded, and anything else is kept as a string
+func FromFlatMapTyped(m map[string]string, sep string) (*Json, error) {
+	return fromFlatMap(m, sep, inferFlatValue)
+}
+
+// MustFromFlatMapTyped is a call to FromFlatMapTyped with a panic on none nil error
+func MustFromFlatMapTyped(m map[string]string, sep string) *Json {
+	js, err := FromFlatMapTyped(m, sep)
+	panic.IfNotNil(err)
+	return js
+}
+
+func fromFlatMap(m map[string]string, sep string, value func(string) interface{}) (*Json, error) {
+	if sep == "" {
+		return nil, errors.New("separator must not be empty")
+	}
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	root := map[string]interface{}{}
+	owners := map[string]string{}
+	for _, k := range keys {
+		segs := strings.Split(k, sep)
+		cur := root
+		for i, seg := range segs {
+			prefix := strings.Join(segs[:i+1], sep)
+			if i == len(segs)-1 {
+				if _, exists := cur[seg]; exists {
+					return nil, fmt.Errorf("key %q conflicts with %q", k, owners[prefix])
+				}
+				cur[seg] = value(m[k])
+				owners[prefix] = k
+				break
+			}
+			child, exists := cur[seg]
+			if !exists {
+				child = map[string]interface{}{}
+				cur[seg] = child
+				owners[prefix] = k
+			}
+			obj, ok := child.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("key %q conflicts with %q", k, owners[prefix])
+			}
+			cur = obj
+		}
+	}
+	return &Json{data: root}, nil
+}
+
+// inferFlatValue converts `s` to the type it looks like as described by FromFlatMapTyped
+func inferFlatValue(s string) interface{} {
+	switch s {
+	case "true":
+		return true
+	case "false":
+		return false
+	case "null":
+		return nil
+	}
+	if n, ok := toNumber(s); ok && strings.TrimSpace(s) == s {
+		return n
+	}
+	if trimmed := strings.TrimSpace(s); strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[") {
+		if js, err := FromString(s); err == nil {
+			return js.data
+		}
+	}
+	return s
+}
+
 // FromFile returns a pointer to a new `Json` object
 // after unmarshaling the contents from `file` into it
 func FromFile(file string) (*Json, error) {
@@ -86,19 +204,34 @@ func MustFromFile(file string) *Json {
 
 // FromReader returns a *Json by decoding from an io.Reader
 func FromReader(r io.Reader) (*Json, error) {
+	return FromReaderWithOptions(r)
+}
+
+// MustFromReader is a call to FromReader with a panic on none nil error
+func MustFromReader(r io.Reader) *Json {
+	js, err := FromReader(r)
+	panic.IfNotNil(err)
+	return js
+}
+
+// FromReaderWithOptions returns a *Json by decoding from an io.Reader configured by
+// `opts`, if `r` is an io.ReadCloser its Close method is called
+//		js, err := json.FromReaderWithOptions(r, json.UseFloat64())
+func FromReaderWithOptions(r io.Reader, opts ...DecodeOption) (*Json, error) {
 	if r == nil {
 		return FromString("null")
 	}
-	rc, ok := r.(io.ReadCloser)
-	if !ok {
-		rc = ioutil.NopCloser(r)
+	if rc, ok := r.(io.ReadCloser); ok {
+		defer rc.Close()
 	}
-	return FromReadCloser(rc)
+	j := &Json{}
+	err := NewDecoder(r, opts...).dec.Decode(&j.data)
+	return j, err
 }
 
-// MustFromReader is a call to FromReader with a panic on none nil error
-func MustFromReader(r io.Reader) *Json {
-	js, err := FromReader(r)
+// MustFromReaderWithOptions is a call to FromReaderWithOptions with a panic on none nil error
+func MustFromReaderWithOptions(r io.Reader, opts ...DecodeOption) *Json {
+	js, err := FromReaderWithOptions(r, opts...)
 	panic.IfNotNil(err)
 	return js
 }
@@ -108,12 +241,7 @@ func FromReadCloser(rc io.ReadCloser) (*Json, error) {
 	if rc == nil {
 		return FromString("null")
 	}
-	defer rc.Close()
-	j := &Json{}
-	dec := json.NewDecoder(rc)
-	dec.UseNumber()
-	err := dec.Decode(&j.data)
-	return j, err
+	return FromReaderWithOptions(rc)
 }
 
 // MustFromReadCloser is a call to FromReadCloser with a panic on none nil error
@@ -150,7 +278,7 @@ func (j *Json) MustToString() string {
 
 // ToPrettyBytes returns its marshaled data as `[]byte` with indentation
 func (j *Json) ToPrettyBytes() ([]byte, error) {
-	return json.MarshalIndent(&j.data, "", "  ")
+	return j.ToPrettyBytesIndent("", "  ")
 }
 
 // MustToPrettyBytes is a call to ToPrettyBytes with a panic on none nil error
@@ -160,10 +288,23 @@ func (j *Json) MustToPrettyBytes() []byte {
 	return bs
 }
 
+// ToPrettyBytesIndent returns its marshaled data as `[]byte` with each line beginning
+// with `prefix` and indented by `indent` per level, as by json.MarshalIndent
+//		js.ToPrettyBytesIndent("", "\t")
+func (j *Json) ToPrettyBytesIndent(prefix, indent string) ([]byte, error) {
+	return json.MarshalIndent(j, prefix, indent)
+}
+
+// MustToPrettyBytesIndent is a call to ToPrettyBytesIndent with a panic on none nil error
+func (j *Json) MustToPrettyBytesIndent(prefix, indent string) []byte {
+	bs, err := j.ToPrettyBytesIndent(prefix, indent)
+	panic.IfNotNil(err)
+	return bs
+}
+
 // ToPrettyString returns its marshaled data as `string` with indentation
 func (j *Json) ToPrettyString() (string, error) {
-	b, err := j.ToPrettyBytes()
-	return string(b), err
+	return j.ToPrettyStringIndent("", "  ")
 }
 
 // MustToPrettyString is a call to ToPrettyString with a panic on none nil error
@@ -173,6 +314,50 @@ func (j *Json) MustToPrettyString() string {
 	return str
 }
 
+// ToPrettyStringIndent returns its marshaled data as `string` with each line beginning
+// with `prefix` and indented by `indent` per level, as by json.MarshalIndent
+//		js.ToPrettyStringIndent("", "    ")
+func (j *Json) ToPrettyStringIndent(prefix, indent string) (string, error) {
+	b, err := j.ToPrettyBytesIndent(prefix, indent)
+	return string(b), err
+}
+
+// MustToPrettyStringIndent is a call to ToPrettyStringIndent with a panic on none nil error
+func (j *Json) MustToPrettyStringIndent(prefix, indent string) string {
+	str, err := j.ToPrettyStringIndent(prefix, indent)
+	panic.IfNotNil(err)
+	return str
+}
+
+// CacheKey returns a hex encoded SHA-256 hash of the document's canonical form, as
+// by ToCanonicalBytes, with the values at `ignorePaths` removed, so documents
+// differing only in key order, number formatting or ignored values share a key.
+// Ignored paths that are not present are skipped.
+//		key, err := req.CacheKey([]interface{}{"timestamp"})
+func (j *Json) CacheKey(ignorePaths ...[]interface{}) (string, error) {
+	tmp := &Json{data: cloneData(j.data)}
+	for _, path := range ignorePaths {
+		if err := tmp.Del(path...); err != nil {
+			if _, ok := err.(*PathError); !ok {
+				return "", err
+			}
+		}
+	}
+	b, err := tmp.ToCanonicalBytes()
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// MustCacheKey is a call to CacheKey with a panic on none nil error
+func (j *Json) MustCacheKey(ignorePaths ...[]interface{}) string {
+	key, err := j.CacheKey(ignorePaths...)
+	panic.IfNotNil(err)
+	return key
+}
+
 // ToFile writes the Json to the `file` with permission `perm`
 func (j *Json) ToFile(file string, perm os.FileMode) error {
 	b, err := j.ToBytes()
@@ -203,16 +388,85 @@ func (j *Json) MustToReader() io.Reader {
 	return r
 }
 
+// WriteTo implements io.WriterTo, encoding its data straight to `w` with a
+// `json.Encoder` and returning the number of bytes written. As with any
+// `json.Encoder` the output is followed by a newline, use WriteToWithoutNewline
+// to suppress it.
+//		js.WriteTo(w)
+func (j *Json) WriteTo(w io.Writer) (int64, error) {
+	return j.writeTo(w, false)
+}
+
+// WriteToWithoutNewline is a call to WriteTo without the trailing newline
+func (j *Json) WriteToWithoutNewline(w io.Writer) (int64, error) {
+	return j.writeTo(w, true)
+}
+
+func (j *Json) writeTo(w io.Writer, trimNewline bool) (int64, error) {
+	cw := &countWriter{w: w, trimNewline: trimNewline}
+	err := json.NewEncoder(cw).Encode(j)
+	return cw.n, err
+}
+
+// countWriter counts the bytes written through it, `json.Encoder` makes a single
+// Write per Encode so a trailing newline can be trimmed from each Write
+type countWriter struct {
+	w           io.Writer
+	n           int64
+	trimNewline bool
+}
+
+func (cw *countWriter) Write(p []byte) (int, error) {
+	trimmed := 0
+	if cw.trimNewline && len(p) > 0 && p[len(p)-1] == '\n' {
+		trimmed = 1
+	}
+	n, err := cw.w.Write(p[:len(p)-trimmed])
+	cw.n += int64(n)
+	if err != nil {
+		return n, err
+	}
+	return n + trimmed, nil
+}
+
 // Implements the json.Marshaler interface.
 func (j *Json) MarshalJSON() ([]byte, error) {
+	if j.order != nil {
+		buf := &bytes.Buffer{}
+		err := encodeOrdered(buf, j.data, j.order)
+		return buf.Bytes(), err
+	}
 	return json.Marshal(&j.data)
 }
 
-// Implements the json.Unmarshaler interface.
+// Implements the json.Unmarshaler interface, on error the existing data is kept.
 func (j *Json) UnmarshalJSON(p []byte) error {
 	jNew, err := FromReader(bytes.NewReader(p))
+	if err != nil {
+		return err
+	}
 	j.data = jNew.data
-	return err
+	return nil
+}
+
+// Implements the sql.Scanner interface, a nil `src`, as from a NULL column, is
+// scanned as JSON null.
+func (j *Json) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		j.data = nil
+		return nil
+	case []byte:
+		return j.UnmarshalJSON(v)
+	case string:
+		return j.UnmarshalJSON([]byte(v))
+	}
+	return fmt.Errorf("unsupported Scan source type %T", src)
+}
+
+// Implements the driver.Valuer interface.
+func (j Json) Value() (driver.Value, error) {
+	return j.MarshalJSON()
 }
 
 // Get searches for the item as specified by the path.
@@ -222,30 +476,33 @@ func (j *Json) UnmarshalJSON(p []byte) error {
 //
 //   js.Get("top_level", "dict", 3, "foo")
 func (j *Json) Get(path ...interface{}) (*Json, error) {
+	if j.nothing {
+		return j, &PathError{[]interface{}{}, path}
+	}
 	tmp := j
 	for i, k := range path {
 		if key, ok := k.(string); ok {
 			if m, err := tmp.Map(); err == nil {
 				if val, ok := m[key]; ok {
-					tmp = &Json{val}
+					tmp = j.wrap(val)
 				} else {
-					return tmp, &jsonPathError{path[:i], path[i:]}
+					return tmp, &PathError{path[:i], path[i:]}
 				}
 			} else {
-				return tmp, &jsonPathError{path[:i], path[i:]}
+				return tmp, &PathError{path[:i], path[i:]}
 			}
 		} else if index, ok := k.(int); ok {
 			if a, err := tmp.Slice(); err == nil {
-				if index < 0 || index >= len(a) {
-					return tmp, &jsonPathError{path[:i], path[i:]}
+				if index = sliceIndex(index, len(a)); index < 0 || index >= len(a) {
+					return tmp, &PathError{path[:i], path[i:]}
 				} else {
-					tmp = &Json{a[index]}
+					tmp = j.wrap(a[index])
 				}
 			} else {
-				return tmp, &jsonPathError{path[:i], path[i:]}
+				return tmp, &PathError{path[:i], path[i:]}
 			}
 		} else {
-			return tmp, &jsonPathError{path[:i], path[i:]}
+			return tmp, &PathError{path[:i], path[i:]}
 		}
 	}
 	return tmp, nil
@@ -258,11 +515,124 @@ func (j *Json) MustGet(path ...interface{}) *Json {
 	return js
 }
 
-// Set modifies `Json`, recursively checking/creating map keys and checking
-// slice indices for the supplied path, and then finally writing in the value.
-// Set will only create maps where the current map[key] does not exist,
-// if the key exists, even if the value is nil, a new map will not be created and an
-// error wil be returned.
+// GetOrDefault guarantees the return of a `*Json` (with specified default)
+//
+// useful when you want to keep chaining calls whether or not `path` is present:
+//		cfg.GetOrDefault(json.MustNew(), "section").StringOrDefault("", "key")
+func (j *Json) GetOrDefault(def *Json, path ...interface{}) *Json {
+	if js, err := j.Get(path...); err == nil {
+		return js
+	}
+	return def
+}
+
+// GetContext is a call to Get that checks `ctx` before navigating each segment of
+// `path`, returning `ctx.Err()` if it is done
+func (j *Json) GetContext(ctx context.Context, path ...interface{}) (*Json, error) {
+	tmp := j
+	for i := range path {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		next, err := tmp.Get(path[i])
+		if err != nil {
+			if _, ok := err.(*PathError); ok {
+				return tmp, &PathError{path[:i], path[i:]}
+			}
+			return tmp, err
+		}
+		tmp = next
+	}
+	return tmp, nil
+}
+
+// MustGetContext is a call to GetContext with a panic on none nil error
+func (j *Json) MustGetContext(ctx context.Context, path ...interface{}) *Json {
+	js, err := j.GetContext(ctx, path...)
+	panic.IfNotNil(err)
+	return js
+}
+
+// GetEmbedded parses the string at `path` as JSON, for values that have been
+// double encoded as stringified JSON inside JSON
+func (j *Json) GetEmbedded(path ...interface{}) (*Json, error) {
+	str, err := j.String(path...)
+	if err != nil {
+		return nil, err
+	}
+	js, err := FromString(str)
+	if err != nil {
+		return nil, fmt.Errorf("embedded value is not valid JSON: %s", err)
+	}
+	js.timeLayout = j.timeLayout
+	return js, nil
+}
+
+// MustGetEmbedded is a call to GetEmbedded with a panic on none nil error
+func (j *Json) MustGetEmbedded(path ...interface{}) *Json {
+	js, err := j.GetEmbedded(path...)
+	panic.IfNotNil(err)
+	return js
+}
+
+// Maybe is a call to Get that never errors, returning a "nothing" `Json` when the
+// path is not present. Nothing is distinct from a JSON null, it holds no value at all:
+// Get on it always returns an error, so further Maybe calls return nothing and the
+// accessor OrDefault variants return their defaults, allowing optional chaining
+//		js.Maybe("a").Maybe("b").StringOrDefault("")
+func (j *Json) Maybe(path ...interface{}) *Json {
+	if tmp, err := j.Get(path...); err == nil {
+		return tmp
+	}
+	return &Json{timeLayout: j.timeLayout, nothing: true}
+}
+
+// IsNothing reports whether `j` is the nothing value returned by Maybe for a missing path
+func (j *Json) IsNothing() bool {
+	return j.nothing
+}
+
+// Exists reports whether a value, including null, is present at `path`
+func (j *Json) Exists(path ...interface{}) bool {
+	_, err := j.Get(path...)
+	return err == nil
+}
+
+// IsNull reports whether the value at `path` is present and null
+func (j *Json) IsNull(path ...interface{}) bool {
+	tmp, err := j.Get(path...)
+	return err == nil && tmp.data == nil
+}
+
+// Require checks that every one of `paths` is present and not null, returning a
+// single error listing all those that are not in dotted form
+//		js.Require([]interface{}{"user", "id"}, []interface{}{"items", 0})
+func (j *Json) Require(paths ...[]interface{}) error {
+	missing := []string{}
+	for _, path := range paths {
+		if v, err := j.Interface(path...); err != nil || v == nil {
+			missing = append(missing, dottedPath(path))
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("missing required paths: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// MustRequire is a call to Require with a panic on none nil error
+func (j *Json) MustRequire(paths ...[]interface{}) {
+	panic.IfNotNil(j.Require(paths...))
+}
+
+// Set modifies `Json`, recursively checking/creating map keys and slice indices
+// for the supplied path, and then finally writing in the value.
+// Set will only create containers where the current map[key] does not exist or
+// the slice index is beyond the end of the slice, creating a map when the next path
+// segment is a string and a slice when it is an int. Slices are grown up to the
+// index, filling with nil. If the key exists, even if the value is nil, a new
+// container will not be created and an error wil be returned. The document is not
+// modified when an error is returned.
 //		j.Set("my", "path", 1, "to-the", "property", value)
 func (j *Json) Set(pathPartsThenValue ...interface{}) error {
 	if len(pathPartsThenValue) == 0 {
@@ -275,48 +645,210 @@ func (j *Json) Set(pathPartsThenValue ...interface{}) error {
 		return nil
 	}
 
-	tmp := j
+	if err := j.checkSetPath(path); err != nil {
+		return err
+	}
+
+	cur := j.data
+	// replace writes a grown slice back into the parent of cur
+	replace := func(v interface{}) { j.data = v }
 
 	for i := 0; i < len(path); i++ {
+		missing := false
 		if key, ok := path[i].(string); ok {
-			if m, err := tmp.Map(); err == nil {
-				if i == len(path)-1 {
-					m[key] = val
-				} else {
-					_, ok := path[i+1].(string)
-					_, exists := m[key]
-					if ok && !exists {
-						m[key] = map[string]interface{}{}
-					}
-					tmp = &Json{m[key]}
-				}
-			} else {
-				return &jsonPathError{path[:i], path[i:]}
+			m, ok := cur.(map[string]interface{})
+			if !ok {
+				return &PathError{path[:i], path[i:]}
+			}
+			if i == len(path)-1 {
+				m[key] = val
+				return nil
 			}
+			_, exists := m[key]
+			missing = !exists
+			cur, replace = m[key], func(v interface{}) { m[key] = v }
 		} else if index, ok := path[i].(int); ok {
-			if a, err := tmp.Slice(); err == nil && index >= 0 && index < len(a) {
-				if i == len(path)-1 {
-					a[index] = val
-				} else {
-					tmp = &Json{a[index]}
-				}
-			} else {
-				return &jsonPathError{path[:i], path[i:]}
+			a, ok := cur.([]interface{})
+			if index = sliceIndex(index, len(a)); !ok || index < 0 {
+				return &PathError{path[:i], path[i:]}
+			}
+			if index >= len(a) {
+				a = append(a, make([]interface{}, index+1-len(a))...)
+				replace(a)
+				missing = true
+			}
+			if i == len(path)-1 {
+				a[index] = val
+				return nil
+			}
+			cur, replace = a[index], func(v interface{}) { a[index] = v }
+		} else {
+			return &PathError{path[:i], path[i:]}
+		}
+		if missing {
+			if _, ok := path[i+1].(string); ok {
+				cur = map[string]interface{}{}
+				replace(cur)
+			} else if index, ok := path[i+1].(int); ok && index >= 0 {
+				cur = []interface{}{}
+				replace(cur)
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkSetPath returns the error Set would return for `path` without making any
+// changes, so that a failing Set leaves the document untouched
+func (j *Json) checkSetPath(path []interface{}) error {
+	cur := j.data
+	for i := 0; i < len(path); i++ {
+		if key, ok := path[i].(string); ok {
+			m, ok := cur.(map[string]interface{})
+			if !ok {
+				return &PathError{path[:i], path[i:]}
+			}
+			if cur, ok = m[key]; !ok {
+				return checkCreatePath(path, i+1)
+			}
+		} else if index, ok := path[i].(int); ok {
+			a, ok := cur.([]interface{})
+			if index = sliceIndex(index, len(a)); !ok || index < 0 {
+				return &PathError{path[:i], path[i:]}
 			}
+			if index >= len(a) {
+				return checkCreatePath(path, i+1)
+			}
+			cur = a[index]
 		} else {
-			return &jsonPathError{path[:i], path[i:]}
+			return &PathError{path[:i], path[i:]}
 		}
 	}
+	return nil
+}
 
+// checkCreatePath returns the error Set would return creating containers for the
+// segments of `path` from `from` on
+func checkCreatePath(path []interface{}, from int) error {
+	for i := from; i < len(path); i++ {
+		if _, ok := path[i].(string); ok {
+			continue
+		}
+		if index, ok := path[i].(int); !ok || index < 0 {
+			return &PathError{path[:i], path[i:]}
+		}
+	}
 	return nil
 }
 
+// setAt is a call to Set with `path` followed by `val` that does not modify
+// the backing array of `path`
+func (j *Json) setAt(path []interface{}, val interface{}) error {
+	return j.Set(append(append(make([]interface{}, 0, len(path)+1), path...), val)...)
+}
+
 // MustSet is a call to Set with a panic on none nil error
 func (j *Json) MustSet(pathPartsThenValue ...interface{}) *Json {
 	panic.IfNotNil(j.Set(pathPartsThenValue...))
 	return j
 }
 
+// SetArray is a call to Set with a fresh empty `[]interface{}` as the value,
+// guaranteeing the value at `path` is an array regardless of what was there before
+//		j.SetArray("my", "list")
+func (j *Json) SetArray(path ...interface{}) error {
+	return j.setAt(path, []interface{}{})
+}
+
+// MustSetArray is a call to SetArray with a panic on none nil error
+func (j *Json) MustSetArray(path ...interface{}) *Json {
+	panic.IfNotNil(j.SetArray(path...))
+	return j
+}
+
+// SetObject is a call to Set with a fresh empty `map[string]interface{}` as the value,
+// guaranteeing the value at `path` is an object regardless of what was there before
+//		j.SetObject("my", "obj")
+func (j *Json) SetObject(path ...interface{}) error {
+	return j.setAt(path, map[string]interface{}{})
+}
+
+// MustSetObject is a call to SetObject with a panic on none nil error
+func (j *Json) MustSetObject(path ...interface{}) *Json {
+	panic.IfNotNil(j.SetObject(path...))
+	return j
+}
+
+// Append adds the last value in `pathPartsThenValue` to the end of the array at the
+// path given by the preceding values, an empty path appends to the root array
+//		j.Append("my", "array", 1)
+func (j *Json) Append(pathPartsThenValue ...interface{}) error {
+	if len(pathPartsThenValue) == 0 {
+		return fmt.Errorf("no value supplied")
+	}
+	path := pathPartsThenValue[:len(pathPartsThenValue)-1]
+	arr, err := j.appended(path, pathPartsThenValue[len(pathPartsThenValue)-1])
+	if err != nil {
+		return err
+	}
+	return j.setAt(path, arr)
+}
+
+// MustAppend is a call to Append with a panic on none nil error
+func (j *Json) MustAppend(pathPartsThenValue ...interface{}) *Json {
+	panic.IfNotNil(j.Append(pathPartsThenValue...))
+	return j
+}
+
+// Insert places the last value in `pathPartsThenIndexThenValue` into the array at
+// the path given by the values before the int index preceding it, shifting the
+// elements from that index onwards right, an index equal to the length appends
+//		j.Insert("my", "array", 0, "first")
+func (j *Json) Insert(pathPartsThenIndexThenValue ...interface{}) error {
+	if len(pathPartsThenIndexThenValue) < 2 {
+		return fmt.Errorf("no index and value supplied")
+	}
+	n := len(pathPartsThenIndexThenValue)
+	path, val := pathPartsThenIndexThenValue[:n-2], pathPartsThenIndexThenValue[n-1]
+	index, ok := pathPartsThenIndexThenValue[n-2].(int)
+	if !ok {
+		return fmt.Errorf("index must be an int")
+	}
+	tmp, err := j.Get(path...)
+	if err != nil {
+		return err
+	}
+	arr, ok := tmp.data.([]interface{})
+	if !ok || index < 0 || index > len(arr) {
+		return &PathError{path, []interface{}{index}}
+	}
+	arr = append(arr, nil)
+	copy(arr[index+1:], arr[index:])
+	arr[index] = val
+	return j.setAt(path, arr)
+}
+
+// MustInsert is a call to Insert with a panic on none nil error
+func (j *Json) MustInsert(pathPartsThenIndexThenValue ...interface{}) *Json {
+	panic.IfNotNil(j.Insert(pathPartsThenIndexThenValue...))
+	return j
+}
+
+// appended returns the array at `path` with `val` appended, a value that is not an
+// array results in a `PathError` with nothing missing
+func (j *Json) appended(path []interface{}, val interface{}) ([]interface{}, error) {
+	tmp, err := j.Get(path...)
+	if err != nil {
+		return nil, err
+	}
+	arr, ok := tmp.data.([]interface{})
+	if !ok {
+		return nil, &PathError{path, []interface{}{}}
+	}
+	return append(arr, val), nil
+}
+
 // Del modifies `Json` maps and slices by deleting/removing the last `path` segment if it is present,
 func (j *Json) Del(path ...interface{}) error {
 	if len(path) == 0 {
@@ -327,21 +859,21 @@ func (j *Json) Del(path ...interface{}) error {
 	i := len(path) - 1
 	tmp, err := j.Get(path[:i]...)
 	if err != nil {
-		err.(*jsonPathError).MissingPath = append(err.(*jsonPathError).MissingPath, path[i])
+		err.(*PathError).MissingPath = append(err.(*PathError).MissingPath, path[i])
 		return err
 	}
 
 	if key, ok := path[i].(string); ok {
 		if m, err := tmp.Map(); err != nil {
-			return &jsonPathError{path[:i], path[i:]}
+			return &PathError{path[:i], path[i:]}
 		} else {
 			delete(m, key)
 		}
 	} else if index, ok := path[i].(int); ok {
 		if a, err := tmp.Slice(); err != nil {
-			return &jsonPathError{path[:i], path[i:]}
-		} else if index < 0 || index >= len(a) {
-			return &jsonPathError{path[:i], path[i:]}
+			return &PathError{path[:i], path[i:]}
+		} else if index = sliceIndex(index, len(a)); index < 0 || index >= len(a) {
+			return &PathError{path[:i], path[i:]}
 		} else {
 			a, a[len(a)-1] = append(a[:index], a[index+1:]...), nil
 			if i == 0 {
@@ -351,12 +883,13 @@ func (j *Json) Del(path ...interface{}) error {
 				if key, ok := path[i-1].(string); ok {
 					tmp.MapOrDefault(nil)[key] = a //is this safe? should be 100% certainty ;)
 				} else if index, ok := path[i-1].(int); ok {
-					tmp.SliceOrDefault(nil)[index] = a //is this safe? should be 100% certainty ;)
+					parent := tmp.SliceOrDefault(nil)
+					parent[sliceIndex(index, len(parent))] = a //is this safe? should be 100% certainty ;)
 				}
 			}
 		}
 	} else {
-		return &jsonPathError{path[:i], path[i:]}
+		return &PathError{path[:i], path[i:]}
 	}
 	return nil
 }
@@ -366,32 +899,135 @@ func (j *Json) MustDel(path ...interface{}) {
 	panic.IfNotNil(j.Del(path...))
 }
 
-// Interface returns the underlying data
-func (j *Json) Interface(path ...interface{}) (interface{}, error) {
-	tmp, err := j.Get(path...)
-	return tmp.data, err
+// Copy sets a deep copy of the value at `from` at `to`, as by Set, leaving the
+// document untouched on error. A failure to Get `from` or Set `to` is returned as
+// is, so the `PathError` identifies the side that failed by its path.
+//		js.Copy([]interface{}{"billing", "address"}, []interface{}{"shipping", "address"})
+func (j *Json) Copy(from, to []interface{}) error {
+	tmp := j.Clone()
+	val, err := tmp.Get(from...)
+	if err != nil {
+		return err
+	}
+	if err := tmp.setAt(to, cloneData(val.data)); err != nil {
+		return err
+	}
+	j.swap(tmp)
+	return nil
 }
 
-// MustInterface is a call to Interface with a panic on none nil error
-func (j *Json) MustInterface(path ...interface{}) interface{} {
-	i, err := j.Interface(path...)
-	panic.IfNotNil(err)
-	return i
+// MustCopy is a call to Copy with a panic on none nil error
+func (j *Json) MustCopy(from, to []interface{}) {
+	panic.IfNotNil(j.Copy(from, to))
 }
 
-// Map type asserts to `map[string]interface{}`
-func (j *Json) Map(path ...interface{}) (map[string]interface{}, error) {
-	tmp, err := j.Get(path...)
+// Move deletes the value at `from`, as by Del, and then sets it at `to`, as by Set,
+// as a JSON Patch move does, so indices in `to` apply after the removal and a value
+// can be moved onto its own ancestor. The document is untouched on error. A value can
+// not be moved into itself and moving a value to its own path does nothing.
+//		js.Move([]interface{}{"user", "mail"}, []interface{}{"user", "email"})
+func (j *Json) Move(from, to []interface{}) error {
+	tmp := j.Clone()
+	val, err := tmp.Get(from...)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	if m, ok := tmp.data.(map[string]interface{}); ok {
-		return m, nil
+	if len(from) <= len(to) && reflect.DeepEqual(from, to[:len(from)]) {
+		if len(from) == len(to) {
+			return nil
+		}
+		return fmt.Errorf("can not move %v into itself at %v", from, to)
 	}
-	return nil, errors.New("type assertion to map[string]interface{} failed")
-}
-
-// MustMap is a call to Map with a panic on none nil error
+	if err := tmp.Del(from...); err != nil {
+		return err
+	}
+	if err := tmp.setAt(to, val.data); err != nil {
+		return err
+	}
+	j.swap(tmp)
+	return nil
+}
+
+// MustMove is a call to Move with a panic on none nil error
+func (j *Json) MustMove(from, to []interface{}) {
+	panic.IfNotNil(j.Move(from, to))
+}
+
+// Pick returns a new `Json` holding copies of only the values at `paths`, nested
+// as they are in `j`, paths that are not present are skipped. This is Pluck for a
+// single document rather than each element of an array.
+//		js.Pick([]interface{}{"user", "name"}, []interface{}{"user", "id"}) => {"user":{"id":1,"name":"bob"}}
+func (j *Json) Pick(paths ...[]interface{}) (*Json, error) {
+	res := j.wrap(map[string]interface{}{})
+	if _, ok := j.data.([]interface{}); ok {
+		res.data = []interface{}{}
+	}
+	for _, path := range paths {
+		val, err := j.Get(path...)
+		if err != nil {
+			continue
+		}
+		// resolve negative indices so they address the same elements in res and
+		// replace nils left by growing slices in res with containers to descend into
+		abs := make([]interface{}, len(path))
+		for i, p := range path {
+			if index, ok := p.(int); ok && index < 0 {
+				p = sliceIndex(index, len(j.SliceOrDefault(nil, path[:i]...)))
+			}
+			abs[i] = p
+			if i == 0 {
+				continue
+			}
+			if v, err := res.Get(abs[:i]...); err == nil && v.data == nil {
+				var container interface{} = map[string]interface{}{}
+				if _, err := j.Slice(path[:i]...); err == nil {
+					container = []interface{}{}
+				}
+				if err := res.setAt(abs[:i], container); err != nil {
+					return nil, err
+				}
+			}
+		}
+		if err := res.setAt(abs, cloneData(val.data)); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// MustPick is a call to Pick with a panic on none nil error
+func (j *Json) MustPick(paths ...[]interface{}) *Json {
+	js, err := j.Pick(paths...)
+	panic.IfNotNil(err)
+	return js
+}
+
+// Interface returns the underlying data
+func (j *Json) Interface(path ...interface{}) (interface{}, error) {
+	tmp, err := j.Get(path...)
+	return tmp.data, err
+}
+
+// MustInterface is a call to Interface with a panic on none nil error
+func (j *Json) MustInterface(path ...interface{}) interface{} {
+	i, err := j.Interface(path...)
+	panic.IfNotNil(err)
+	return i
+}
+
+// Map type asserts to `map[string]interface{}`
+func (j *Json) Map(path ...interface{}) (map[string]interface{}, error) {
+	tmp, err := j.Get(path...)
+	if err != nil {
+		return nil, err
+	}
+	if m, ok := tmp.data.(map[string]interface{}); ok {
+		return m, nil
+	}
+	return nil, typeError(path, "map[string]interface{}", tmp.data)
+}
+
+// MustMap is a call to Map with a panic on none nil error
 func (j *Json) MustMap(path ...interface{}) map[string]interface{} {
 	v, err := j.Map(path...)
 	panic.IfNotNil(err)
@@ -423,12 +1059,12 @@ func (j *Json) MapString(path ...interface{}) (map[string]string, error) {
 			if kStr, ok := v.(string); ok {
 				ms[k] = kStr
 			} else {
-				return nil, errors.New("type assertion of map value to string failed")
+				return nil, fmt.Errorf("key %q: %w", k, typeError(path, "string", v, k))
 			}
 		}
 		return ms, nil
 	}
-	return nil, errors.New("type assertion to map[string]string{} failed")
+	return nil, typeError(path, "map[string]interface{}", tmp.data)
 }
 
 // MustMapString is a call to MapString with a panic on none nil error
@@ -451,6 +1087,58 @@ func (j *Json) MapStringOrDefault(def map[string]string, path ...interface{}) ma
 	return def
 }
 
+// MapStringSortedJSON returns the object at `path` as compact JSON with its keys in
+// sorted order, returning an error if any of its values are not strings
+func (j *Json) MapStringSortedJSON(path ...interface{}) (string, error) {
+	m, err := j.MapString(path...)
+	if err != nil {
+		return "", err
+	}
+	b, err := json.Marshal(m)
+	return string(b), err
+}
+
+// MustMapStringSortedJSON is a call to MapStringSortedJSON with a panic on none nil error
+func (j *Json) MustMapStringSortedJSON(path ...interface{}) string {
+	str, err := j.MapStringSortedJSON(path...)
+	panic.IfNotNil(err)
+	return str
+}
+
+// Keys returns the keys of the object at `path`, they are not sorted and their
+// order is not guaranteed to be the same between calls
+func (j *Json) Keys(path ...interface{}) ([]string, error) {
+	m, err := j.Map(path...)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return keys, nil
+}
+
+// MustKeys is a call to Keys with a panic on none nil error
+func (j *Json) MustKeys(path ...interface{}) []string {
+	v, err := j.Keys(path...)
+	panic.IfNotNil(err)
+	return v
+}
+
+// KeysOrDefault guarantees the return of a `[]string` (with specified default)
+//
+// useful when you want to iterate over object keys in a succinct manner:
+//		for _, k := range js.KeysOrDefault(nil) {
+//			fmt.Println(k)
+//		}
+func (j *Json) KeysOrDefault(def []string, path ...interface{}) []string {
+	if keys, err := j.Keys(path...); err == nil {
+		return keys
+	}
+	return def
+}
+
 // Slice type asserts to a `slice`
 func (j *Json) Slice(path ...interface{}) ([]interface{}, error) {
 	tmp, err := j.Get(path...)
@@ -460,7 +1148,7 @@ func (j *Json) Slice(path ...interface{}) ([]interface{}, error) {
 	if a, ok := tmp.data.([]interface{}); ok {
 		return a, nil
 	}
-	return nil, errors.New("type assertion to []interface{} failed")
+	return nil, typeError(path, "[]interface{}", tmp.data)
 }
 
 // MustSlice is a call to MustSlice with a panic on none nil error
@@ -483,6 +1171,176 @@ func (j *Json) SliceOrDefault(def []interface{}, path ...interface{}) []interfac
 	return def
 }
 
+// MapSlice type asserts to a `slice` of `map[string]interface{}`
+func (j *Json) MapSlice(path ...interface{}) ([]map[string]interface{}, error) {
+	arr, err := j.Slice(path...)
+	if err != nil {
+		return nil, err
+	}
+	ms := make([]map[string]interface{}, 0, len(arr))
+	for i, a := range arr {
+		if m, ok := a.(map[string]interface{}); ok {
+			ms = append(ms, m)
+		} else {
+			return nil, fmt.Errorf("element %d: %w", i, typeError(path, "map[string]interface{}", a, i))
+		}
+	}
+	return ms, nil
+}
+
+// MustMapSlice is a call to MapSlice with a panic on none nil error
+func (j *Json) MustMapSlice(path ...interface{}) []map[string]interface{} {
+	v, err := j.MapSlice(path...)
+	panic.IfNotNil(err)
+	return v
+}
+
+// MapSliceOrDefault guarantees the return of a `[]map[string]interface{}` (with specified default)
+//
+// useful when you want to iterate over slice values in a succinct manner:
+//		for i, m := range js.MapSliceOrDefault(nil) {
+//			fmt.Println(i, m)
+//		}
+func (j *Json) MapSliceOrDefault(def []map[string]interface{}, path ...interface{}) []map[string]interface{} {
+	if a, err := j.MapSlice(path...); err == nil {
+		return a
+	}
+	return def
+}
+
+// JsonSlice returns the elements of the array at `path` each wrapped in a `Json`
+func (j *Json) JsonSlice(path ...interface{}) ([]*Json, error) {
+	arr, err := j.Slice(path...)
+	if err != nil {
+		return nil, err
+	}
+	js := make([]*Json, 0, len(arr))
+	for _, a := range arr {
+		js = append(js, j.wrap(a))
+	}
+	return js, nil
+}
+
+// MustJsonSlice is a call to JsonSlice with a panic on none nil error
+func (j *Json) MustJsonSlice(path ...interface{}) []*Json {
+	v, err := j.JsonSlice(path...)
+	panic.IfNotNil(err)
+	return v
+}
+
+// JsonSliceOrDefault guarantees the return of a `[]*Json` (with specified default)
+//
+// useful when you want to iterate over slice values in a succinct manner:
+//		for _, item := range js.JsonSliceOrDefault(nil, "results") {
+//			fmt.Println(item.MustString("name"))
+//		}
+func (j *Json) JsonSliceOrDefault(def []*Json, path ...interface{}) []*Json {
+	if a, err := j.JsonSlice(path...); err == nil {
+		return a
+	}
+	return def
+}
+
+// IsEmptyContainer reports whether the value at `path` is an empty object or array,
+// returning an error if it is neither an object nor an array
+func (j *Json) IsEmptyContainer(path ...interface{}) (bool, error) {
+	tmp, err := j.Get(path...)
+	if err != nil {
+		return false, err
+	}
+	switch v := tmp.data.(type) {
+	case map[string]interface{}:
+		return len(v) == 0, nil
+	case []interface{}:
+		return len(v) == 0, nil
+	}
+	return false, typeError(path, "object or array", tmp.data)
+}
+
+// MustIsEmptyContainer is a call to IsEmptyContainer with a panic on none nil error
+func (j *Json) MustIsEmptyContainer(path ...interface{}) bool {
+	v, err := j.IsEmptyContainer(path...)
+	panic.IfNotNil(err)
+	return v
+}
+
+// ForEach calls `fn` with each child of the object or array at `path`, object keys
+// are passed as strings in sorted order and array indices as ints, iteration stops at
+// and returns the first error returned by `fn`
+//		js.ForEach(func(key interface{}, value *json.Json) error {
+//			fmt.Println(key, value.MustToString())
+//			return nil
+//		}, "my", "container")
+func (j *Json) ForEach(fn func(key interface{}, value *Json) error, path ...interface{}) error {
+	tmp, err := j.Get(path...)
+	if err != nil {
+		return err
+	}
+	switch v := tmp.data.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if err := fn(k, j.wrap(v[k])); err != nil {
+				return err
+			}
+		}
+		return nil
+	case []interface{}:
+		for i, e := range v {
+			if err := fn(i, j.wrap(e)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return typeError(path, "object or array", tmp.data)
+}
+
+// MustForEach is a call to ForEach with a panic on none nil error
+func (j *Json) MustForEach(fn func(key interface{}, value *Json) error, path ...interface{}) {
+	panic.IfNotNil(j.ForEach(fn, path...))
+}
+
+// Len returns the number of elements in the array, keys in the object or runes in
+// the string at `path`
+func (j *Json) Len(path ...interface{}) (int, error) {
+	tmp, err := j.Get(path...)
+	if err != nil {
+		return 0, err
+	}
+	switch v := tmp.data.(type) {
+	case map[string]interface{}:
+		return len(v), nil
+	case []interface{}:
+		return len(v), nil
+	case string:
+		return utf8.RuneCountInString(v), nil
+	}
+	return 0, typeError(path, "array, object or string", tmp.data)
+}
+
+// MustLen is a call to Len with a panic on none nil error
+func (j *Json) MustLen(path ...interface{}) int {
+	v, err := j.Len(path...)
+	panic.IfNotNil(err)
+	return v
+}
+
+// LenOrDefault guarantees the return of an `int` (with specified default)
+//
+// useful when you explicitly want an `int` in a single value return context:
+//     myFunc(js.LenOrDefault(0))
+func (j *Json) LenOrDefault(def int, path ...interface{}) int {
+	if l, err := j.Len(path...); err == nil {
+		return l
+	}
+	return def
+}
+
 // Bool type asserts to `bool`
 func (j *Json) Bool(path ...interface{}) (bool, error) {
 	tmp, err := j.Get(path...)
@@ -492,7 +1350,7 @@ func (j *Json) Bool(path ...interface{}) (bool, error) {
 	if s, ok := tmp.data.(bool); ok {
 		return s, nil
 	}
-	return false, errors.New("type assertion to bool failed")
+	return false, typeError(path, "bool", tmp.data)
 }
 
 // MustBool is a call to Bool with a panic on none nil error
@@ -522,7 +1380,7 @@ func (j *Json) String(path ...interface{}) (string, error) {
 	if s, ok := tmp.data.(string); ok {
 		return s, nil
 	}
-	return "", errors.New("type assertion to string failed")
+	return "", typeError(path, "string", tmp.data)
 }
 
 // MustString is a call to String with a panic on none nil error
@@ -543,6 +1401,78 @@ func (j *Json) StringOrDefault(def string, path ...interface{}) string {
 	return def
 }
 
+// StringOrNumberString returns a string as is or a number in its string form, so an
+// id reads the same whether it was sent as "123" or 123
+//
+// it is one of the lenient accessors for inconsistent upstreams that each accept the
+// two most common representations of a type: StringOrNumberString, BoolOrBoolString,
+// and the number accessors such as Int64 and Float64 which also accept numeric strings
+func (j *Json) StringOrNumberString(path ...interface{}) (string, error) {
+	tmp, err := j.Get(path...)
+	if err != nil {
+		return "", err
+	}
+	if s, ok := tmp.data.(string); ok {
+		return s, nil
+	}
+	if n, ok := toNumber(tmp.data); ok {
+		return n.String(), nil
+	}
+	return "", typeError(path, "string or number", tmp.data)
+}
+
+// MustStringOrNumberString is a call to StringOrNumberString with a panic on none nil error
+func (j *Json) MustStringOrNumberString(path ...interface{}) string {
+	v, err := j.StringOrNumberString(path...)
+	panic.IfNotNil(err)
+	return v
+}
+
+// StringOrNumberStringOrDefault guarantees the return of a `string` (with specified default)
+//
+// useful when you explicitly want a `string` in a single value return context:
+//     myFunc(js.StringOrNumberStringOrDefault("my_default"))
+func (j *Json) StringOrNumberStringOrDefault(def string, path ...interface{}) string {
+	if s, err := j.StringOrNumberString(path...); err == nil {
+		return s
+	}
+	return def
+}
+
+// BoolOrBoolString returns a bool as is or parses a string such as "true" or "0"
+// with strconv.ParseBool, see StringOrNumberString for the other lenient accessors
+func (j *Json) BoolOrBoolString(path ...interface{}) (bool, error) {
+	tmp, err := j.Get(path...)
+	if err != nil {
+		return false, err
+	}
+	switch v := tmp.data.(type) {
+	case bool:
+		return v, nil
+	case string:
+		return strconv.ParseBool(v)
+	}
+	return false, typeError(path, "bool or bool string", tmp.data)
+}
+
+// MustBoolOrBoolString is a call to BoolOrBoolString with a panic on none nil error
+func (j *Json) MustBoolOrBoolString(path ...interface{}) bool {
+	v, err := j.BoolOrBoolString(path...)
+	panic.IfNotNil(err)
+	return v
+}
+
+// BoolOrBoolStringOrDefault guarantees the return of a `bool` (with specified default)
+//
+// useful when you explicitly want a `bool` in a single value return context:
+//     myFunc(js.BoolOrBoolStringOrDefault(true))
+func (j *Json) BoolOrBoolStringOrDefault(def bool, path ...interface{}) bool {
+	if b, err := j.BoolOrBoolString(path...); err == nil {
+		return b
+	}
+	return def
+}
+
 // StringSlice type asserts to a `slice` of `string`
 func (j *Json) StringSlice(path ...interface{}) ([]string, error) {
 	arr, err := j.Slice(path...)
@@ -550,9 +1480,9 @@ func (j *Json) StringSlice(path ...interface{}) ([]string, error) {
 		return nil, err
 	}
 	retArr := make([]string, 0, len(arr))
-	for _, a := range arr {
+	for i, a := range arr {
 		if s, ok := a.(string); a == nil || !ok {
-			return nil, errors.New("none string value encountered")
+			return nil, fmt.Errorf("element %d: %w", i, typeError(path, "string", a, i))
 		} else {
 			retArr = append(retArr, s)
 		}
@@ -580,8 +1510,26 @@ func (j *Json) StringSliceOrDefault(def []string, path ...interface{}) []string
 	return def
 }
 
-// Time type asserts to `time.Time`
+// WithTimeLayout returns a view of the `Json` sharing the same underlying data,
+// whose Time and TimeSlice calls, and those of any `Json` returned from its Get,
+// first try to parse string values with `layout` before falling back to RFC 3339
+//		js.WithTimeLayout("2006-01-02").Time("created")
+func (j *Json) WithTimeLayout(layout string) *Json {
+	view := *j
+	view.timeLayout = layout
+	return &view
+}
+
+// Time type asserts to `time.Time`, or unmarshals a string value using the layout
+// set by WithTimeLayout, falling back to RFC 3339
 func (j *Json) Time(path ...interface{}) (time.Time, error) {
+	return j.TimeWithLayout(j.timeLayout, path...)
+}
+
+// TimeWithLayout type asserts to `time.Time`, or unmarshals a string value using
+// `layout`, falling back to RFC 3339, an empty `layout` goes straight to RFC 3339
+//		js.TimeWithLayout("2006-01-02", "dob")
+func (j *Json) TimeWithLayout(layout string, path ...interface{}) (time.Time, error) {
 	var t time.Time
 	tmp, err := j.Get(path...)
 	if err != nil {
@@ -590,11 +1538,23 @@ func (j *Json) Time(path ...interface{}) (time.Time, error) {
 	if t, ok := tmp.data.(time.Time); ok {
 		return t, nil
 	} else if tStr, ok := tmp.data.(string); ok {
+		if layout != "" {
+			if t, err := time.Parse(layout, tStr); err == nil {
+				return t, nil
+			}
+		}
 		if t.UnmarshalText([]byte(tStr)) == nil {
 			return t, nil
 		}
 	}
-	return t, errors.New("type assertion/unmarshalling to time.Time failed")
+	return t, typeError(path, "time.Time", tmp.data)
+}
+
+// MustTimeWithLayout is a call to TimeWithLayout with a panic on none nil error
+func (j *Json) MustTimeWithLayout(layout string, path ...interface{}) time.Time {
+	v, err := j.TimeWithLayout(layout, path...)
+	panic.IfNotNil(err)
+	return v
 }
 
 // MustTime is a call to Time with a panic on none nil error
@@ -615,23 +1575,37 @@ func (j *Json) TimeOrDefault(def time.Time, path ...interface{}) time.Time {
 	return def
 }
 
-// TimeSlice type asserts to a `slice` of `time.Time`
+// TimeSlice type asserts to a `slice` of `time.Time`, unmarshalling string values
+// in the same way as Time
 func (j *Json) TimeSlice(path ...interface{}) ([]time.Time, error) {
+	return j.TimeSliceWithLayout(j.timeLayout, path...)
+}
+
+// TimeSliceWithLayout is like TimeSlice but parses each element as by TimeWithLayout
+func (j *Json) TimeSliceWithLayout(layout string, path ...interface{}) ([]time.Time, error) {
 	arr, err := j.Slice(path...)
 	if err != nil {
 		return nil, err
 	}
 	retArr := make([]time.Time, 0, len(arr))
-	for _, a := range arr {
-		if s, ok := a.(time.Time); a == nil || !ok {
-			return nil, errors.New("none time.Time value encountered")
+	for i, a := range arr {
+		tmp := j.wrap(a)
+		if t, err := tmp.TimeWithLayout(layout); err != nil {
+			return nil, fmt.Errorf("element %d: %w", i, typeError(path, "time.Time", a, i))
 		} else {
-			retArr = append(retArr, s)
+			retArr = append(retArr, t)
 		}
 	}
 	return retArr, nil
 }
 
+// MustTimeSliceWithLayout is a call to TimeSliceWithLayout with a panic on none nil error
+func (j *Json) MustTimeSliceWithLayout(layout string, path ...interface{}) []time.Time {
+	v, err := j.TimeSliceWithLayout(layout, path...)
+	panic.IfNotNil(err)
+	return v
+}
+
 // MustTimeSlice is a call to TimeSlice with a panic on none nil error
 func (j *Json) MustTimeSlice(path ...interface{}) []time.Time {
 	v, err := j.TimeSlice(path...)
@@ -652,14 +1626,94 @@ func (j *Json) TimeSliceOrDefault(def []time.Time, path ...interface{}) []time.T
 	return def
 }
 
-// Duration type asserts to `time.Duration`
+// TimeUnix reads a numeric value as a count of seconds since the Unix epoch and
+// returns the corresponding `time.Time` in UTC, fractional seconds are kept to the
+// nanosecond
+//		js.TimeUnix("iat")
+func (j *Json) TimeUnix(path ...interface{}) (time.Time, error) {
+	return j.unixTime(int64(time.Second), path...)
+}
+
+// MustTimeUnix is a call to TimeUnix with a panic on none nil error
+func (j *Json) MustTimeUnix(path ...interface{}) time.Time {
+	v, err := j.TimeUnix(path...)
+	panic.IfNotNil(err)
+	return v
+}
+
+// TimeUnixOrDefault guarantees the return of a `time.Time` (with specified default)
+//
+// useful when you explicitly want a `time.Time` in a single value return context:
+//     myFunc(js.TimeUnixOrDefault(defaultTime, "iat"))
+func (j *Json) TimeUnixOrDefault(def time.Time, path ...interface{}) time.Time {
+	if t, err := j.TimeUnix(path...); err == nil {
+		return t
+	}
+	return def
+}
+
+// TimeUnixMilli reads a numeric value as a count of milliseconds since the Unix
+// epoch and returns the corresponding `time.Time` in UTC
+//		js.TimeUnixMilli("createdAt")
+func (j *Json) TimeUnixMilli(path ...interface{}) (time.Time, error) {
+	return j.unixTime(int64(time.Millisecond), path...)
+}
+
+// MustTimeUnixMilli is a call to TimeUnixMilli with a panic on none nil error
+func (j *Json) MustTimeUnixMilli(path ...interface{}) time.Time {
+	v, err := j.TimeUnixMilli(path...)
+	panic.IfNotNil(err)
+	return v
+}
+
+// TimeUnixMilliOrDefault guarantees the return of a `time.Time` (with specified default)
+//
+// useful when you explicitly want a `time.Time` in a single value return context:
+//     myFunc(js.TimeUnixMilliOrDefault(defaultTime, "createdAt"))
+func (j *Json) TimeUnixMilliOrDefault(def time.Time, path ...interface{}) time.Time {
+	if t, err := j.TimeUnixMilli(path...); err == nil {
+		return t
+	}
+	return def
+}
+
+// unixTime reads a numeric value as a count of `unit` nanoseconds since the Unix epoch
+func (j *Json) unixTime(unit int64, path ...interface{}) (time.Time, error) {
+	n, err := j.Number(path...)
+	if err != nil {
+		return time.Time{}, err
+	}
+	r, _ := numberRat(n)
+	r.Mul(r, new(big.Rat).SetInt64(unit))
+	ns := new(big.Int).Quo(r.Num(), r.Denom())
+	sec, nsec := new(big.Int).QuoRem(ns, big.NewInt(int64(time.Second)), new(big.Int))
+	if !sec.IsInt64() {
+		return time.Time{}, fmt.Errorf("value %s is out of range for a unix time", n)
+	}
+	return time.Unix(sec.Int64(), nsec.Int64()).UTC(), nil
+}
+
+// Duration type asserts to `time.Duration`, parsing strings such as `"1s"` with
+// time.ParseDuration and treating numbers as an integer count of nanoseconds, as
+// `time.Duration` marshals by default
 func (j *Json) Duration(path ...interface{}) (time.Duration, error) {
-	var d time.Duration
-	tmp, err := j.String(path...)
+	tmp, err := j.Get(path...)
 	if err != nil {
-		return d, err
+		return 0, err
+	}
+	switch v := tmp.data.(type) {
+	case string:
+		return time.ParseDuration(v)
+	case time.Duration:
+		return v, nil
+	}
+	if _, ok := toNumber(tmp.data); ok {
+		if n, ok := exactInt64(tmp.data); ok {
+			return time.Duration(n), nil
+		}
+		return 0, fmt.Errorf("value %v is not an integer count of nanoseconds", tmp.data)
 	}
-	return time.ParseDuration(tmp)
+	return 0, typeError(path, "string or number", tmp.data)
 }
 
 // MustDuration is a call to Duration with a panic on none nil error
@@ -682,13 +1736,13 @@ func (j *Json) DurationOrDefault(def time.Duration, path ...interface{}) time.Du
 
 // DurationSlice type asserts to a `slice` of `time.Duration`
 func (j *Json) DurationSlice(path ...interface{}) ([]time.Duration, error) {
-	arr, err := j.StringSlice(path...)
+	arr, err := j.Slice(path...)
 	if err != nil {
 		return nil, err
 	}
 	retArr := make([]time.Duration, 0, len(arr))
 	for _, a := range arr {
-		if d, err := time.ParseDuration(a); err != nil {
+		if d, err := j.wrap(a).Duration(); err != nil {
 			return nil, err
 		} else {
 			retArr = append(retArr, d)
@@ -704,17 +1758,183 @@ func (j *Json) MustDurationSlice(path ...interface{}) []time.Duration {
 	return v
 }
 
-// DurationSliceOrDefault guarantees the return of a `[]time.Duration` (with specified default)
-//
-// useful when you want to iterate over slice values in a succinct manner:
-//		for i, t := range js.DurationSliceOrDefault(nil) {
-//			fmt.Println(i, t)
-//		}
-func (j *Json) DurationSliceOrDefault(def []time.Duration, path ...interface{}) []time.Duration {
-	if a, err := j.DurationSlice(path...); err == nil {
-		return a
+// DurationSliceOrDefault guarantees the return of a `[]time.Duration` (with specified default)
+//
+// useful when you want to iterate over slice values in a succinct manner:
+//		for i, t := range js.DurationSliceOrDefault(nil) {
+//			fmt.Println(i, t)
+//		}
+func (j *Json) DurationSliceOrDefault(def []time.Duration, path ...interface{}) []time.Duration {
+	if a, err := j.DurationSlice(path...); err == nil {
+		return a
+	}
+	return def
+}
+
+// Bytes decodes the standard base64 encoded string at `path`
+func (j *Json) Bytes(path ...interface{}) ([]byte, error) {
+	str, err := j.String(path...)
+	if err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.DecodeString(str)
+}
+
+// MustBytes is a call to Bytes with a panic on none nil error
+func (j *Json) MustBytes(path ...interface{}) []byte {
+	v, err := j.Bytes(path...)
+	panic.IfNotNil(err)
+	return v
+}
+
+// BytesOrDefault guarantees the return of a `[]byte` (with specified default)
+//
+// useful when you explicitly want a `[]byte` in a single value return context:
+//     myFunc(js.BytesOrDefault(nil))
+func (j *Json) BytesOrDefault(def []byte, path ...interface{}) []byte {
+	if b, err := j.Bytes(path...); err == nil {
+		return b
+	}
+	return def
+}
+
+// SetBytes is a call to Set with the last value, a `[]byte`, stored as its standard
+// base64 encoding so that it can be read back with Bytes
+//		j.SetBytes("file", "content", []byte("hello"))
+func (j *Json) SetBytes(pathPartsThenValue ...interface{}) error {
+	if len(pathPartsThenValue) == 0 {
+		return fmt.Errorf("no value supplied")
+	}
+	last := len(pathPartsThenValue) - 1
+	b, ok := pathPartsThenValue[last].([]byte)
+	if !ok {
+		return fmt.Errorf("value must be a []byte")
+	}
+	return j.setAt(pathPartsThenValue[:last], base64.StdEncoding.EncodeToString(b))
+}
+
+// MustSetBytes is a call to SetBytes with a panic on none nil error
+func (j *Json) MustSetBytes(pathPartsThenValue ...interface{}) *Json {
+	panic.IfNotNil(j.SetBytes(pathPartsThenValue...))
+	return j
+}
+
+// ByteSize coerces a data size such as `"10MB"`, `"1.5GiB"` or `"512k"` into a
+// count of bytes. Decimal (k, M, G, T, P, E) and binary (Ki, Mi, Gi, Ti, Pi, Ei)
+// unit prefixes are supported, with or without a trailing B, case insensitively.
+// Numeric values are taken to already be a count of bytes.
+func (j *Json) ByteSize(path ...interface{}) (int64, error) {
+	tmp, err := j.Get(path...)
+	if err != nil {
+		return 0, err
+	}
+	str, ok := tmp.data.(string)
+	if !ok {
+		if v, ok := exactInt64(tmp.data); ok {
+			return v, nil
+		}
+		return 0, typeError(path, "string or number", tmp.data)
+	}
+	return ParseByteSize(str)
+}
+
+// MustByteSize is a call to ByteSize with a panic on none nil error
+func (j *Json) MustByteSize(path ...interface{}) int64 {
+	v, err := j.ByteSize(path...)
+	panic.IfNotNil(err)
+	return v
+}
+
+// ByteSizeOrDefault guarantees the return of an `int64` (with specified default)
+//
+// useful when you explicitly want an `int64` in a single value return context:
+//     myFunc(js.ByteSizeOrDefault(1024))
+func (j *Json) ByteSizeOrDefault(def int64, path ...interface{}) int64 {
+	if v, err := j.ByteSize(path...); err == nil {
+		return v
+	}
+	return def
+}
+
+// SetByteSize is a call to Set with the last value, a whole number count of bytes
+// of any numeric type that fits in an `int64`, formatted by FormatByteSize
+//		j.SetByteSize("cache", "max", 10<<20) // "10MiB"
+func (j *Json) SetByteSize(pathPartsThenValue ...interface{}) error {
+	if len(pathPartsThenValue) == 0 {
+		return fmt.Errorf("no value supplied")
+	}
+	last := len(pathPartsThenValue) - 1
+	val := pathPartsThenValue[last]
+	n, ok := exactInt64(val)
+	if _, isStr := val.(string); isStr || !ok {
+		return fmt.Errorf("value must be a whole number count of bytes that fits in an int64")
+	}
+	return j.setAt(pathPartsThenValue[:last], FormatByteSize(n))
+}
+
+// MustSetByteSize is a call to SetByteSize with a panic on none nil error
+func (j *Json) MustSetByteSize(pathPartsThenValue ...interface{}) *Json {
+	panic.IfNotNil(j.SetByteSize(pathPartsThenValue...))
+	return j
+}
+
+var byteSizeUnits = []struct {
+	suffix string
+	size   int64
+}{
+	{"Ei", 1 << 60}, {"E", 1e18},
+	{"Pi", 1 << 50}, {"P", 1e15},
+	{"Ti", 1 << 40}, {"T", 1e12},
+	{"Gi", 1 << 30}, {"G", 1e9},
+	{"Mi", 1 << 20}, {"M", 1e6},
+	{"Ki", 1 << 10}, {"K", 1e3},
+}
+
+// ParseByteSize parses a data size such as `"10MB"` into a count of bytes, see ByteSize
+func ParseByteSize(str string) (int64, error) {
+	s := strings.TrimSpace(str)
+	upper := strings.ToUpper(s)
+	if strings.HasSuffix(upper, "B") {
+		upper = upper[:len(upper)-1]
+		s = s[:len(s)-1]
+	}
+	size := int64(1)
+	for _, u := range byteSizeUnits {
+		if strings.HasSuffix(upper, strings.ToUpper(u.suffix)) {
+			size = u.size
+			s = s[:len(s)-len(u.suffix)]
+			break
+		}
+	}
+	r, ok := new(big.Rat).SetString(strings.TrimSpace(s))
+	if !ok || !json.Valid([]byte(strings.TrimSpace(s))) {
+		return 0, fmt.Errorf("invalid byte size %q", str)
+	}
+	r.Mul(r, new(big.Rat).SetInt64(size))
+	if !r.IsInt() || !r.Num().IsInt64() {
+		return 0, fmt.Errorf("byte size %q is not a whole number of bytes that fits in an int64", str)
+	}
+	return r.Num().Int64(), nil
+}
+
+// FormatByteSize formats `n` bytes using the largest binary, then decimal, unit that
+// divides it exactly, such as `"10MiB"`, `"3kB"` or `"1023B"`
+func FormatByteSize(n int64) string {
+	if n != 0 {
+		for _, unit := range []int{0, 1} {
+			for i := unit; i < len(byteSizeUnits); i += 2 {
+				u := byteSizeUnits[i]
+				if n%u.size == 0 {
+					suffix := u.suffix
+					if suffix == "K" {
+						suffix = "k"
+					}
+					return strconv.FormatInt(n/u.size, 10) + suffix + "B"
+				}
+			}
+		}
 	}
-	return def
+	return strconv.FormatInt(n, 10) + "B"
 }
 
 // Int coerces into an int
@@ -749,7 +1969,7 @@ func (j *Json) IntSlice(path ...interface{}) ([]int, error) {
 	}
 	retArr := make([]int, 0, len(arr))
 	for _, a := range arr {
-		tmp := &Json{a}
+		tmp := &Json{data: a}
 		if i, err := tmp.Int(); err != nil {
 			return nil, err
 		} else {
@@ -779,6 +1999,44 @@ func (j *Json) IntSliceOrDefault(def []int, path ...interface{}) []int {
 	return def
 }
 
+// IntSliceStrict type asserts to a `slice` of `int`, returning an error identifying
+// the offending element if any element is not an integer that fits in an `int`
+func (j *Json) IntSliceStrict(path ...interface{}) ([]int, error) {
+	arr, err := j.Slice(path...)
+	if err != nil {
+		return nil, err
+	}
+	retArr := make([]int, 0, len(arr))
+	for i, a := range arr {
+		if v, ok := exactInt64(a); !ok || int64(int(v)) != v {
+			return nil, fmt.Errorf("element %d value %v does not convert exactly to int", i, a)
+		} else {
+			retArr = append(retArr, int(v))
+		}
+	}
+	return retArr, nil
+}
+
+// MustIntSliceStrict is a call to IntSliceStrict with a panic on none nil error
+func (j *Json) MustIntSliceStrict(path ...interface{}) []int {
+	v, err := j.IntSliceStrict(path...)
+	panic.IfNotNil(err)
+	return v
+}
+
+// IntSliceStrictOrDefault guarantees the return of a `[]int` (with specified default)
+//
+// useful when you want to iterate over slice values in a succinct manner:
+//		for i, s := range js.IntSliceStrictOrDefault(nil) {
+//			fmt.Println(i, s)
+//		}
+func (j *Json) IntSliceStrictOrDefault(def []int, path ...interface{}) []int {
+	if a, err := j.IntSliceStrict(path...); err == nil {
+		return a
+	}
+	return def
+}
+
 // Float64 coerces into a float64
 func (j *Json) Float64(path ...interface{}) (float64, error) {
 	tmp, err := j.Get(path...)
@@ -797,7 +2055,7 @@ func (j *Json) Float64(path ...interface{}) (float64, error) {
 	case uint, uint8, uint16, uint32, uint64:
 		return float64(reflect.ValueOf(tmp.data).Uint()), nil
 	}
-	return 0, errors.New("invalid value type")
+	return 0, typeError(path, "number", tmp.data)
 }
 
 // MustFloat64 is a call to Float64 with a panic on none nil error
@@ -826,7 +2084,7 @@ func (j *Json) Float64Slice(path ...interface{}) ([]float64, error) {
 	}
 	retArr := make([]float64, 0, len(arr))
 	for _, a := range arr {
-		tmp := &Json{a}
+		tmp := &Json{data: a}
 		if f, err := tmp.Float64(); err != nil {
 			return nil, err
 		} else {
@@ -874,7 +2132,7 @@ func (j *Json) Int64(path ...interface{}) (int64, error) {
 	case uint, uint8, uint16, uint32, uint64:
 		return int64(reflect.ValueOf(tmp.data).Uint()), nil
 	}
-	return 0, errors.New("invalid value type")
+	return 0, typeError(path, "number", tmp.data)
 }
 
 // MustInt64 is a call to Int64 with a panic on none nil error
@@ -903,7 +2161,7 @@ func (j *Json) Int64Slice(path ...interface{}) ([]int64, error) {
 	}
 	retArr := make([]int64, 0, len(arr))
 	for _, a := range arr {
-		tmp := &Json{a}
+		tmp := &Json{data: a}
 		if i, err := tmp.Int64(); err != nil {
 			return nil, err
 		} else {
@@ -933,6 +2191,44 @@ func (j *Json) Int64SliceDefault(def []int64, path ...interface{}) []int64 {
 	return def
 }
 
+// Int64SliceStrict type asserts to a `slice` of `int64`, returning an error identifying
+// the offending element if any element is not an integer that fits in an `int64`
+func (j *Json) Int64SliceStrict(path ...interface{}) ([]int64, error) {
+	arr, err := j.Slice(path...)
+	if err != nil {
+		return nil, err
+	}
+	retArr := make([]int64, 0, len(arr))
+	for i, a := range arr {
+		if v, ok := exactInt64(a); !ok {
+			return nil, fmt.Errorf("element %d value %v does not convert exactly to int64", i, a)
+		} else {
+			retArr = append(retArr, v)
+		}
+	}
+	return retArr, nil
+}
+
+// MustInt64SliceStrict is a call to Int64SliceStrict with a panic on none nil error
+func (j *Json) MustInt64SliceStrict(path ...interface{}) []int64 {
+	v, err := j.Int64SliceStrict(path...)
+	panic.IfNotNil(err)
+	return v
+}
+
+// Int64SliceStrictOrDefault guarantees the return of a `[]int64` (with specified default)
+//
+// useful when you want to iterate over slice values in a succinct manner:
+//		for i, s := range js.Int64SliceStrictOrDefault(nil) {
+//			fmt.Println(i, s)
+//		}
+func (j *Json) Int64SliceStrictOrDefault(def []int64, path ...interface{}) []int64 {
+	if a, err := j.Int64SliceStrict(path...); err == nil {
+		return a
+	}
+	return def
+}
+
 // Uint64 coerces into an uint64
 func (j *Json) Uint64(path ...interface{}) (uint64, error) {
 	tmp, err := j.Get(path...)
@@ -951,7 +2247,7 @@ func (j *Json) Uint64(path ...interface{}) (uint64, error) {
 	case uint, uint8, uint16, uint32, uint64:
 		return reflect.ValueOf(tmp.data).Uint(), nil
 	}
-	return 0, errors.New("invalid value type")
+	return 0, typeError(path, "number", tmp.data)
 }
 
 // MustUint64 is a call to Uint64 with a panic on none nil error
@@ -980,7 +2276,7 @@ func (j *Json) Uint64Slice(path ...interface{}) ([]uint64, error) {
 	}
 	retArr := make([]uint64, 0, len(arr))
 	for _, a := range arr {
-		tmp := &Json{a}
+		tmp := &Json{data: a}
 		if u, err := tmp.Uint64(); err != nil {
 			return nil, err
 		} else {
@@ -1010,11 +2306,402 @@ func (j *Json) Uint64SliceOrDefault(def []uint64, path ...interface{}) []uint64
 	return def
 }
 
-type jsonPathError struct {
+// Uint coerces into a `uint` as by Uint64, returning an error for negative numbers and
+// for values that do not fit in a `uint` on the current platform
+func (j *Json) Uint(path ...interface{}) (uint, error) {
+	tmp, err := j.Get(path...)
+	if err != nil {
+		return 0, err
+	}
+	switch tmp.data.(type) {
+	case float32, float64:
+		if reflect.ValueOf(tmp.data).Float() < 0 {
+			return 0, typeError(path, "uint", tmp.data)
+		}
+	case int, int8, int16, int32, int64:
+		if reflect.ValueOf(tmp.data).Int() < 0 {
+			return 0, typeError(path, "uint", tmp.data)
+		}
+	}
+	u, err := tmp.Uint64()
+	if err != nil {
+		return 0, err
+	}
+	if u > math.MaxUint {
+		return 0, fmt.Errorf("value %d overflows uint", u)
+	}
+	return uint(u), nil
+}
+
+// MustUint is a call to Uint with a panic on none nil error
+func (j *Json) MustUint(path ...interface{}) uint {
+	v, err := j.Uint(path...)
+	panic.IfNotNil(err)
+	return v
+}
+
+// UintOrDefault guarantees the return of a `uint` (with specified default)
+//
+// useful when you explicitly want a `uint` in a single value return context:
+//     myFunc(js.UintOrDefault(5150))
+func (j *Json) UintOrDefault(def uint, path ...interface{}) uint {
+	if i, err := j.Uint(path...); err == nil {
+		return i
+	}
+	return def
+}
+
+// UintSlice type asserts to a `slice` of `uint`
+func (j *Json) UintSlice(path ...interface{}) ([]uint, error) {
+	arr, err := j.Slice(path...)
+	if err != nil {
+		return nil, err
+	}
+	retArr := make([]uint, 0, len(arr))
+	for _, a := range arr {
+		tmp := &Json{data: a}
+		if u, err := tmp.Uint(); err != nil {
+			return nil, err
+		} else {
+			retArr = append(retArr, u)
+		}
+	}
+	return retArr, nil
+}
+
+// MustUintSlice is a call to UintSlice with a panic on none nil error
+func (j *Json) MustUintSlice(path ...interface{}) []uint {
+	v, err := j.UintSlice(path...)
+	panic.IfNotNil(err)
+	return v
+}
+
+// UintSliceOrDefault guarantees the return of a `[]uint` (with specified default)
+//
+// useful when you want to iterate over slice values in a succinct manner:
+//		for i, s := range js.UintSliceOrDefault(nil) {
+//			fmt.Println(i, s)
+//		}
+func (j *Json) UintSliceOrDefault(def []uint, path ...interface{}) []uint {
+	if a, err := j.UintSlice(path...); err == nil {
+		return a
+	}
+	return def
+}
+
+// exactInt64 converts a numeric value, or a string containing a JSON number, to an
+// `int64`, reporting false if it is not integral or does not fit in an `int64`
+func exactInt64(v interface{}) (int64, bool) {
+	if str, ok := v.(string); ok {
+		if !json.Valid([]byte(str)) {
+			return 0, false
+		}
+		v = json.Number(str)
+	}
+	r, ok := numberRat(v)
+	if !ok || !r.IsInt() || !r.Num().IsInt64() {
+		return 0, false
+	}
+	return r.Num().Int64(), true
+}
+
+// wrap returns a new `Json` around `data` sharing the settings of `j`
+func (j *Json) wrap(data interface{}) *Json {
+	return &Json{data: data, timeLayout: j.timeLayout}
+}
+
+// cloneData returns a deep copy of `data`, recursively copying maps and slices
+// and copying all other values as is
+func cloneData(data interface{}) interface{} {
+	switch v := data.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			m[k] = cloneData(e)
+		}
+		return m
+	case []interface{}:
+		a := make([]interface{}, len(v))
+		for i, e := range v {
+			a[i] = cloneData(e)
+		}
+		return a
+	}
+	return data
+}
+
+// Number coerces into a `json.Number`, returning a `json.Number` or a numeric string
+// with its digits exactly as they are and converting other numeric types, so integers
+// beyond the precision of a `float64` can be handled without loss
+func (j *Json) Number(path ...interface{}) (json.Number, error) {
+	tmp, err := j.Get(path...)
+	if err != nil {
+		return "", err
+	}
+	if n, ok := toNumber(tmp.data); ok {
+		return n, nil
+	}
+	return "", typeError(path, "number", tmp.data)
+}
+
+// MustNumber is a call to Number with a panic on none nil error
+func (j *Json) MustNumber(path ...interface{}) json.Number {
+	v, err := j.Number(path...)
+	panic.IfNotNil(err)
+	return v
+}
+
+// NumberOrDefault guarantees the return of a `json.Number` (with specified default)
+//
+// useful when you explicitly want a `json.Number` in a single value return context:
+//     myFunc(js.NumberOrDefault("0"))
+func (j *Json) NumberOrDefault(def json.Number, path ...interface{}) json.Number {
+	if n, err := j.Number(path...); err == nil {
+		return n
+	}
+	return def
+}
+
+// BigInt coerces into a `*big.Int` as by Number, for integers beyond the range of
+// `uint64`, returning an error for numbers with a fractional part
+func (j *Json) BigInt(path ...interface{}) (*big.Int, error) {
+	n, err := j.Number(path...)
+	if err != nil {
+		return nil, err
+	}
+	if i, ok := new(big.Int).SetString(n.String(), 10); ok {
+		return i, nil
+	}
+	r, ok := numberRat(n)
+	if !ok || !r.IsInt() {
+		return nil, fmt.Errorf("value %s is not an integer", n)
+	}
+	return new(big.Int).Set(r.Num()), nil
+}
+
+// MustBigInt is a call to BigInt with a panic on none nil error
+func (j *Json) MustBigInt(path ...interface{}) *big.Int {
+	v, err := j.BigInt(path...)
+	panic.IfNotNil(err)
+	return v
+}
+
+// BigIntOrDefault guarantees the return of a `*big.Int` (with specified default)
+//
+// useful when you explicitly want a `*big.Int` in a single value return context:
+//     myFunc(js.BigIntOrDefault(big.NewInt(0)))
+func (j *Json) BigIntOrDefault(def *big.Int, path ...interface{}) *big.Int {
+	if i, err := j.BigInt(path...); err == nil {
+		return i
+	}
+	return def
+}
+
+// BigFloat coerces into a `*big.Float` as by Number, with enough precision to hold
+// every digit of the source so decimals avoid the rounding of a `float64`
+func (j *Json) BigFloat(path ...interface{}) (*big.Float, error) {
+	n, err := j.Number(path...)
+	if err != nil {
+		return nil, err
+	}
+	f, _, err := big.ParseFloat(n.String(), 10, uint(len(n))*4+64, big.ToNearestEven)
+	return f, err
+}
+
+// MustBigFloat is a call to BigFloat with a panic on none nil error
+func (j *Json) MustBigFloat(path ...interface{}) *big.Float {
+	v, err := j.BigFloat(path...)
+	panic.IfNotNil(err)
+	return v
+}
+
+// BigFloatOrDefault guarantees the return of a `*big.Float` (with specified default)
+//
+// useful when you explicitly want a `*big.Float` in a single value return context:
+//     myFunc(js.BigFloatOrDefault(big.NewFloat(0)))
+func (j *Json) BigFloatOrDefault(def *big.Float, path ...interface{}) *big.Float {
+	if f, err := j.BigFloat(path...); err == nil {
+		return f
+	}
+	return def
+}
+
+// NumberSlice coerces into a `slice` of `json.Number`, preserving the digits of
+// `json.Number` and numeric string elements exactly
+func (j *Json) NumberSlice(path ...interface{}) ([]json.Number, error) {
+	arr, err := j.Slice(path...)
+	if err != nil {
+		return nil, err
+	}
+	retArr := make([]json.Number, 0, len(arr))
+	for i, a := range arr {
+		if n, err := (&Json{data: a}).Number(); err != nil {
+			return nil, fmt.Errorf("element %d value %v is not a number", i, a)
+		} else {
+			retArr = append(retArr, n)
+		}
+	}
+	return retArr, nil
+}
+
+// MustNumberSlice is a call to NumberSlice with a panic on none nil error
+func (j *Json) MustNumberSlice(path ...interface{}) []json.Number {
+	v, err := j.NumberSlice(path...)
+	panic.IfNotNil(err)
+	return v
+}
+
+// NumberSliceOrDefault guarantees the return of a `[]json.Number` (with specified default)
+//
+// useful when you want to iterate over slice values in a succinct manner:
+//		for i, n := range js.NumberSliceOrDefault(nil) {
+//			fmt.Println(i, n)
+//		}
+func (j *Json) NumberSliceOrDefault(def []json.Number, path ...interface{}) []json.Number {
+	if a, err := j.NumberSlice(path...); err == nil {
+		return a
+	}
+	return def
+}
+
+// Unmarshal navigates to the value at `path` and unmarshals it into `target`, which
+// must be a pointer, honoring struct tags
+//		var user User
+//		err := js.Unmarshal(&user, "results", 0)
+func (j *Json) Unmarshal(target interface{}, path ...interface{}) error {
+	tmp, err := j.Get(path...)
+	if err != nil {
+		return err
+	}
+	b, err := json.Marshal(tmp.data)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, target)
+}
+
+// MustUnmarshal is a call to Unmarshal with a panic on none nil error
+func (j *Json) MustUnmarshal(target interface{}, path ...interface{}) {
+	panic.IfNotNil(j.Unmarshal(target, path...))
+}
+
+// SetStruct marshals `v` and stores the decoded result at `path`, so that structs
+// and other typed values are held as the same maps, slices and `json.Number`s as
+// the rest of the document and can be read back with Get, Map, Slice, etc.
+//		err := js.SetStruct([]interface{}{"results", 0}, user)
+func (j *Json) SetStruct(path []interface{}, v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	tmp, err := FromBytes(b)
+	if err != nil {
+		return err
+	}
+	return j.setAt(path, tmp.data)
+}
+
+// MustSetStruct is a call to SetStruct with a panic on none nil error
+func (j *Json) MustSetStruct(path []interface{}, v interface{}) *Json {
+	panic.IfNotNil(j.SetStruct(path, v))
+	return j
+}
+
+// UnmarshalSlice navigates to the array at `path` and unmarshals each of its elements
+// into a `T`, honoring struct tags
+//		users, err := json.UnmarshalSlice[User](js, "results")
+func UnmarshalSlice[T any](j *Json, path ...interface{}) ([]T, error) {
+	arr, err := j.Slice(path...)
+	if err != nil {
+		return nil, err
+	}
+	retArr := make([]T, 0, len(arr))
+	for i, a := range arr {
+		var t T
+		if err := j.wrap(a).Unmarshal(&t); err != nil {
+			return nil, fmt.Errorf("element %d: %w", i, err)
+		}
+		retArr = append(retArr, t)
+	}
+	return retArr, nil
+}
+
+// MustUnmarshalSlice is a call to UnmarshalSlice with a panic on none nil error
+func MustUnmarshalSlice[T any](j *Json, path ...interface{}) []T {
+	v, err := UnmarshalSlice[T](j, path...)
+	panic.IfNotNil(err)
+	return v
+}
+
+// toNumber converts a numeric value, or a string containing a JSON number, to a `json.Number`
+func toNumber(v interface{}) (json.Number, bool) {
+	switch n := v.(type) {
+	case json.Number:
+		return n, true
+	case string:
+		if _, ok := numberRat(json.Number(n)); ok && json.Valid([]byte(n)) {
+			return json.Number(n), true
+		}
+	case float32, float64:
+		f := reflect.ValueOf(n).Float()
+		if _, ok := numberRat(f); ok {
+			bits := 64
+			if _, is32 := n.(float32); is32 {
+				bits = 32
+			}
+			return json.Number(strconv.FormatFloat(f, 'g', -1, bits)), true
+		}
+	case int, int8, int16, int32, int64:
+		return json.Number(strconv.FormatInt(reflect.ValueOf(n).Int(), 10)), true
+	case uint, uint8, uint16, uint32, uint64:
+		return json.Number(strconv.FormatUint(reflect.ValueOf(n).Uint(), 10)), true
+	}
+	return "", false
+}
+
+// sliceIndex resolves a negative `index` to count back from the end of a slice of
+// `length` elements, so -1 is the final element
+func sliceIndex(index, length int) int {
+	if index < 0 {
+		return length + index
+	}
+	return index
+}
+
+// TypeError is returned by the typed accessors when the value at `Path` is not of
+// the `Expected` type, `Actual` being the Go type of the value found. Accessors
+// converting the elements of an array or object wrap it with the offending element.
+//		var te *json.TypeError
+//		if errors.As(err, &te) {
+//			fmt.Println(te.Path, te.Actual)
+//		}
+type TypeError struct {
+	Path     []interface{}
+	Expected string
+	Actual   string
+}
+
+func (e *TypeError) Error() string {
+	return fmt.Sprintf("type assertion to %s failed", e.Expected)
+}
+
+// typeError returns a `TypeError` for `v` found at `path` followed by `sub`
+func typeError(path []interface{}, expected string, v interface{}, sub ...interface{}) error {
+	fullPath := append(append(make([]interface{}, 0, len(path)+len(sub)), path...), sub...)
+	return &TypeError{fullPath, expected, fmt.Sprintf("%T", v)}
+}
+
+// PathError is returned when a path can not be navigated, `FoundPath` holding the
+// segments that were found and `MissingPath` the rest
+//		var pe *json.PathError
+//		if errors.As(err, &pe) {
+//			fmt.Println(pe.MissingPath)
+//		}
+type PathError struct {
 	FoundPath   []interface{}
 	MissingPath []interface{}
 }
 
-func (e *jsonPathError) Error() string {
+func (e *PathError) Error() string {
 	return fmt.Sprintf("found: %v missing: %v", e.FoundPath, e.MissingPath)
 }
@@ -1,9 +1,19 @@
 package json
 
 import (
+	"bytes"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
 	"github.com/stretchr/testify/assert"
+	"io"
+	"math/big"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 )
@@ -38,6 +48,59 @@ func Test_FromInterface(t *testing.T) {
 	a.Equal("{}", str2, "str2 is an empty json object string")
 }
 
+func Test_Clone(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`{"a":[{"b":1.50}],"c":"x"}`)
+	a.Nil(err, "err is nil")
+	now := time.Now()
+	obj.MustSet("t", now)
+
+	clone := obj.Clone()
+	clone.MustSet("a", 0, "b", 2)
+	clone.MustAppend("a", true)
+	clone.MustSet("c", "y")
+	clone.MustDel("t")
+
+	a.Equal(json.Number("1.50"), obj.MustInterface("a", 0, "b"), "json.Number is preserved")
+	a.Equal(now, obj.MustInterface("t"), "time.Time is preserved")
+	a.Equal(json.Number("1.50"), obj.Clone().MustInterface("a", 0, "b"), "clone preserves json.Number")
+	a.Equal(now, obj.Clone().MustInterface("t"), "clone preserves time.Time")
+	a.Equal(`{"a":[{"b":1.50}],"c":"x","t":`+obj.MustGet("t").MustToString()+`}`, obj.MustToString(), "original is not mutated")
+	a.Equal(`{"a":[{"b":2},true],"c":"y"}`, clone.MustToString(), "clone is mutated")
+}
+
+func Test_FromFlatMap(t *testing.T) {
+	a := assert.New(t)
+
+	m := map[string]string{"db_host": "x", "db_port": "5432", "db_opts_ssl": "true", "name": "app"}
+	obj, err := FromFlatMap(m, "_")
+	a.Nil(err, "err is nil")
+	a.Equal(`{"db":{"host":"x","opts":{"ssl":"true"},"port":"5432"},"name":"app"}`, obj.MustToString(), "str is correct value")
+
+	_, err = FromFlatMap(map[string]string{"a": "1", "a.b": "2"}, ".")
+	a.Equal(`key "a.b" conflicts with "a"`, err.Error(), "error message is correct")
+	_, err = FromFlatMap(m, "")
+	a.Equal("separator must not be empty", err.Error(), "error message is correct")
+}
+
+func Test_FromFlatMapTyped(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromFlatMapTyped(map[string]string{
+		"a/b": "5432",
+		"a/c": "true",
+		"d":   `{"e":[1]}`,
+		"f":   "null",
+		"g":   "{not json",
+		"h":   " 1",
+		"i":   "x",
+	}, "/")
+	a.Nil(err, "err is nil")
+	a.Equal(`{"a":{"b":5432,"c":true},"d":{"e":[1]},"f":null,"g":"{not json","h":" 1","i":"x"}`, obj.MustToString(), "str is correct value")
+	a.Equal(5432, MustFromFlatMapTyped(map[string]string{"p": "5432"}, ".").MustInt("p"), "number is inferred")
+}
+
 func Test_FromFile(t *testing.T) {
 	a := assert.New(t)
 
@@ -100,6 +163,38 @@ func Test_FromReadCloser_Nil(t *testing.T) {
 	MustFromReadCloser(nil)
 }
 
+func Test_FromReaderWithOptions(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromReaderWithOptions(strings.NewReader(`{"a":12345678901234567890,"b":1.5}`))
+	a.Nil(err, "err is nil")
+	a.Equal(json.Number("12345678901234567890"), obj.MustInterface("a"), "numbers are json.Number by default")
+
+	obj, err = FromReaderWithOptions(strings.NewReader(`{"a":12345678901234567890,"b":1.5}`), UseFloat64())
+	a.Nil(err, "err is nil")
+	a.Equal(float64(12345678901234567890), obj.MustInterface("a"), "numbers are float64")
+	a.Equal(1.5, obj.MustFloat64("b"), "accessors still work")
+
+	rc := &closeRecorder{Reader: strings.NewReader(`[1]`)}
+	a.Equal(`[1]`, MustFromReaderWithOptions(rc).MustToString(), "str is correct value")
+	a.True(rc.closed, "io.ReadCloser is closed")
+
+	_, err = FromReaderWithOptions(strings.NewReader(`{`), UseFloat64())
+	a.NotNil(err, "err is not nil")
+	a.Equal("null", MustFromReaderWithOptions(nil).MustToString(), "nil reader is null")
+	a.Panics(func() { MustFromReaderWithOptions(strings.NewReader(`[`)) }, "panics on error")
+}
+
+type closeRecorder struct {
+	io.Reader
+	closed bool
+}
+
+func (c *closeRecorder) Close() error {
+	c.closed = true
+	return nil
+}
+
 func Test_UnmarshalJSON(t *testing.T) {
 	a := assert.New(t)
 
@@ -124,6 +219,94 @@ func Test_UnmarshalJSON_WithMalformedJson(t *testing.T) {
 	a.Equal("null", str, "str is json null value")
 }
 
+func Test_UnmarshalJSON_WithMalformedJsonKeepsData(t *testing.T) {
+	a := assert.New(t)
+
+	obj := MustFromString(`{"a":1}`)
+	err := obj.UnmarshalJSON([]byte("{"))
+	a.NotNil(err, "err is not nil")
+	a.Equal(`{"a":1}`, obj.MustToString(), "existing data is kept")
+
+	err = json.Unmarshal([]byte(`{"b":[1,}`), obj)
+	a.NotNil(err, "err is not nil")
+	a.Equal(`{"a":1}`, obj.MustToString(), "existing data is kept")
+}
+
+func Test_Scan(t *testing.T) {
+	a := assert.New(t)
+
+	var _ sql.Scanner = &Json{}
+	var _ driver.Valuer = Json{}
+
+	for _, src := range []interface{}{[]byte(`{"a":[1,2]}`), `{"a":[1,2]}`} {
+		obj := &Json{}
+		err := obj.Scan(src)
+		a.Nil(err, "err is nil")
+		exp := &Json{}
+		a.Nil(exp.UnmarshalJSON([]byte(`{"a":[1,2]}`)), "err is nil")
+		a.Equal(exp.data, obj.data, "data matches UnmarshalJSON")
+	}
+
+	obj := FromInterface(map[string]interface{}{"a": 1})
+	err := obj.Scan(nil)
+	a.Nil(err, "err is nil")
+	a.Nil(obj.data, "nil src is json null")
+	a.Equal("null", obj.MustToString(), "str is json null value")
+
+	err = obj.Scan([]byte("{"))
+	a.NotNil(err, "err is not nil")
+	a.Equal("null", obj.MustToString(), "str is json null value")
+
+	err = obj.Scan(1)
+	a.Equal("unsupported Scan source type int", err.Error(), "error message is correct")
+}
+
+func Test_Value(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`{"b":1,"a":"x"}`)
+	a.Nil(err, "err is nil")
+
+	val, err := obj.Value()
+	a.Nil(err, "err is nil")
+	a.Equal([]byte(`{"a":"x","b":1}`), val, "val is the marshaled bytes")
+
+	scanned := &Json{}
+	a.Nil(scanned.Scan(val), "err is nil")
+	a.True(obj.Equal(scanned), "value scans back to an equal document")
+}
+
+func Test_WriteTo(t *testing.T) {
+	a := assert.New(t)
+
+	var _ io.WriterTo = &Json{}
+	obj, err := FromString(`{"b":1,"a":"<x>"}`)
+	a.Nil(err, "err is nil")
+
+	buf := &bytes.Buffer{}
+	n, err := obj.WriteTo(buf)
+	a.Nil(err, "err is nil")
+	a.Equal("{\"a\":\"\\u003cx\\u003e\",\"b\":1}\n", buf.String(), "output matches json.Encoder")
+	a.Equal(int64(buf.Len()), n, "n is the bytes written")
+
+	buf.Reset()
+	n, err = obj.WriteToWithoutNewline(buf)
+	a.Nil(err, "err is nil")
+	a.Equal(obj.MustToString(), buf.String(), "newline is suppressed")
+	a.Equal(int64(buf.Len()), n, "n is the bytes written")
+
+	buf.Reset()
+	n, err = MustFromBytesKeepOrder([]byte(`{"b":1,"a":"<x>"}`)).WriteTo(buf)
+	a.Nil(err, "err is nil")
+	a.Equal("{\"b\":1,\"a\":\"\\u003cx\\u003e\"}\n", buf.String(), "key order is kept")
+	a.Equal(int64(buf.Len()), n, "n is the bytes written")
+
+	obj.MustSet("c", func() {})
+	n, err = obj.WriteTo(&bytes.Buffer{})
+	a.NotNil(err, "err is not nil")
+	a.Zero(n, "nothing is written")
+}
+
 func Test_ToPrettyString(t *testing.T) {
 	a := assert.New(t)
 
@@ -137,6 +320,46 @@ func Test_ToPrettyString(t *testing.T) {
 	obj.MustToPrettyString()
 }
 
+func Test_ToPrettyStringIndent(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`{"a":[1],"b":2}`)
+	a.Nil(err, "err is nil")
+
+	str, err := obj.ToPrettyStringIndent("", "\t")
+	a.Nil(err, "err is nil")
+	a.Equal("{\n\t\"a\": [\n\t\t1\n\t],\n\t\"b\": 2\n}", str, "str is tab indented")
+	a.Equal("{\n>    \"a\": [\n>        1\n>    ],\n>    \"b\": 2\n>}", obj.MustToPrettyStringIndent(">", "    "), "prefix and indent are used")
+	a.Equal(obj.MustToPrettyString(), obj.MustToPrettyStringIndent("", "  "), "ToPrettyString is two space indented")
+
+	bs, err := obj.ToPrettyBytesIndent("", "\t")
+	a.Nil(err, "err is nil")
+	a.Equal([]byte(str), bs, "bytes match string")
+	a.Equal(bs, obj.MustToPrettyBytesIndent("", "\t"), "must bytes match")
+
+	obj.MustSet("c", func() {})
+	_, err = obj.ToPrettyStringIndent("", "\t")
+	a.NotNil(err, "err is not nil")
+	a.Panics(func() { obj.MustToPrettyBytesIndent("", "\t") }, "panics on error")
+}
+
+func Test_CacheKey(t *testing.T) {
+	a := assert.New(t)
+
+	obj1 := MustFromString(`{"q":"shoes","page":{"n":1,"size":20},"timestamp":100}`)
+	obj2 := MustFromString(`{"timestamp":200,"page":{"size":20,"n":1},"q":"shoes"}`)
+	obj3 := MustFromString(`{"q":"hats","page":{"n":1,"size":20},"timestamp":100}`)
+
+	key1, err := obj1.CacheKey([]interface{}{"timestamp"})
+	a.Nil(err, "err is nil")
+	a.Len(key1, 64, "key is a hex sha256")
+	a.Equal(key1, obj2.MustCacheKey([]interface{}{"timestamp"}, []interface{}{"missing", 0}), "keys match when ignored values differ")
+	a.NotEqual(key1, obj3.MustCacheKey([]interface{}{"timestamp"}), "keys differ when values differ")
+	a.NotEqual(key1, obj2.MustCacheKey(), "keys differ when nothing is ignored")
+	a.Equal(200, obj2.MustInt("timestamp"), "ignored values are not removed from the document")
+	a.Equal(key1, MustFromString(`{"q":"shoes","page":{"n":1.0,"size":2e1}}`).MustCacheKey(), "keys match when number formatting differs")
+}
+
 func Test_ToReader(t *testing.T) {
 	a := assert.New(t)
 
@@ -169,6 +392,61 @@ func Test_Get(t *testing.T) {
 	obj2.MustString()
 }
 
+func Test_GetContext(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`{"a":[{"b":1}]}`)
+	a.Nil(err, "err is nil")
+
+	js, err := obj.GetContext(context.Background(), "a", 0, "b")
+	a.Nil(err, "err is nil")
+	a.Equal(1, js.MustInt(), "value is correct")
+	a.Equal(obj, obj.MustGetContext(context.Background()), "empty path is the root")
+
+	_, err = obj.GetContext(context.Background(), "a", 1, "b")
+	a.Equal([]interface{}{"a"}, err.(*PathError).FoundPath, "error FoundPath is correct")
+	a.Equal([]interface{}{1, "b"}, err.(*PathError).MissingPath, "error MissingPath is correct")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = obj.GetContext(ctx, "a")
+	a.Equal(context.Canceled, err, "err is context error")
+}
+
+func Test_GetEmbedded(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`{"a":"{\"b\":[1,2]}","c":"{oops","d":1}`)
+	a.Nil(err, "err is nil")
+
+	emb, err := obj.GetEmbedded("a")
+	a.Nil(err, "err is nil")
+	a.Equal(2, emb.MustInt("b", 1), "embedded value is parsed")
+
+	emb, err = obj.GetEmbedded("c")
+	a.Nil(emb, "emb is nil")
+	a.Contains(err.Error(), "embedded value is not valid JSON: ", "error message is correct")
+
+	_, err = obj.GetEmbedded("d")
+	a.Equal("type assertion to string failed", err.Error(), "error message is correct")
+	_, err = obj.GetEmbedded("e")
+	a.NotNil(err, "err is not nil")
+}
+
+func Test_GetOrDefault(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`{"section":{"key":"v"}}`)
+	a.Nil(err, "err is nil")
+
+	def := MustFromString(`{"key":"default"}`)
+	a.Equal("v", obj.GetOrDefault(def, "section").MustString("key"), "value is returned")
+	a.Equal("default", obj.GetOrDefault(def, "missing").MustString("key"), "default is returned")
+	a.True(def == obj.GetOrDefault(def, "section", "key", "x"), "default is returned as is")
+	a.Nil(obj.GetOrDefault(nil, "missing"), "nil default is returned")
+	a.Equal("v", obj.WithTimeLayout("2006").GetOrDefault(nil).MustString("section", "key"), "empty path is the root")
+}
+
 func Test_Get_WithMissingMapKey(t *testing.T) {
 	a := assert.New(t)
 
@@ -177,14 +455,34 @@ func Test_Get_WithMissingMapKey(t *testing.T) {
 
 	obj, pathErr := obj.Get("a", 1, "b", 2, "d")
 	a.NotNil(pathErr, "err is not nil")
-	a.Equal([]interface{}{"a", 1, "b", 2}, pathErr.(*jsonPathError).FoundPath, "error FoundPath is correct")
-	a.Equal([]interface{}{"d"}, pathErr.(*jsonPathError).MissingPath, "error FoundPath is correct")
+	a.Equal([]interface{}{"a", 1, "b", 2}, pathErr.(*PathError).FoundPath, "error FoundPath is correct")
+	a.Equal([]interface{}{"d"}, pathErr.(*PathError).MissingPath, "error FoundPath is correct")
 
 	str, err := obj.ToString()
 	a.Nil(err, "err is nil")
 	a.Equal(`{"c":"got it!"}`, str, "str is correct value")
 }
 
+func Test_PathError_ErrorsAs(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`{"a":{"b":[1]}}`)
+	a.Nil(err, "err is nil")
+
+	var pe *PathError
+	for _, err := range []error{
+		func() error { _, err := obj.Get("a", "b", 0, "c"); return err }(),
+		func() error { _, err := obj.Int("a", "b", 0, "c"); return err }(),
+		obj.Set("a", "b", 0, "c", 1),
+		obj.Del("a", "b", 0, "c"),
+	} {
+		a.True(errors.As(err, &pe), "err is a PathError")
+		a.Equal([]interface{}{"a", "b", 0}, pe.FoundPath, "error FoundPath is correct")
+		a.Equal([]interface{}{"c"}, pe.MissingPath, "error MissingPath is correct")
+	}
+	a.Equal("found: [a b 0] missing: [c]", pe.Error(), "error message is correct")
+}
+
 func Test_Get_WithInappropriateMapKey(t *testing.T) {
 	a := assert.New(t)
 
@@ -193,8 +491,8 @@ func Test_Get_WithInappropriateMapKey(t *testing.T) {
 
 	obj, pathErr := obj.Get("a", 1, "b", "c")
 	a.NotNil(pathErr, "err is not nil")
-	a.Equal([]interface{}{"a", 1, "b"}, pathErr.(*jsonPathError).FoundPath, "error FoundPath is correct")
-	a.Equal([]interface{}{"c"}, pathErr.(*jsonPathError).MissingPath, "error FoundPath is correct")
+	a.Equal([]interface{}{"a", 1, "b"}, pathErr.(*PathError).FoundPath, "error FoundPath is correct")
+	a.Equal([]interface{}{"c"}, pathErr.(*PathError).MissingPath, "error FoundPath is correct")
 
 	str, err := obj.ToString()
 	a.Nil(err, "err is nil")
@@ -209,8 +507,8 @@ func Test_Get_WithOutOfBoundsSliceIndex(t *testing.T) {
 
 	obj, pathErr := obj.Get("a", 1, "b", 0, 0)
 	a.NotNil(pathErr, "err is not nil")
-	a.Equal([]interface{}{"a", 1, "b", 0}, pathErr.(*jsonPathError).FoundPath, "error FoundPath is correct")
-	a.Equal([]interface{}{0}, pathErr.(*jsonPathError).MissingPath, "error FoundPath is correct")
+	a.Equal([]interface{}{"a", 1, "b", 0}, pathErr.(*PathError).FoundPath, "error FoundPath is correct")
+	a.Equal([]interface{}{0}, pathErr.(*PathError).MissingPath, "error FoundPath is correct")
 
 	str, err := obj.ToString()
 	a.Nil(err, "err is nil")
@@ -225,8 +523,8 @@ func Test_Get_WithInappropriateSliceIndex(t *testing.T) {
 
 	obj, pathErr := obj.Get("a", 1, 0, "b")
 	a.NotNil(pathErr, "err is not nil")
-	a.Equal([]interface{}{"a", 1}, pathErr.(*jsonPathError).FoundPath, "error FoundPath is correct")
-	a.Equal([]interface{}{0, "b"}, pathErr.(*jsonPathError).MissingPath, "error FoundPath is correct")
+	a.Equal([]interface{}{"a", 1}, pathErr.(*PathError).FoundPath, "error FoundPath is correct")
+	a.Equal([]interface{}{0, "b"}, pathErr.(*PathError).MissingPath, "error FoundPath is correct")
 
 	str, err := obj.ToString()
 	a.Nil(err, "err is nil")
@@ -241,14 +539,68 @@ func Test_Get_WithInappropriatePathValue(t *testing.T) {
 
 	obj, pathErr := obj.Get("a", 1, true)
 	a.NotNil(pathErr, "err is not nil")
-	a.Equal([]interface{}{"a", 1}, pathErr.(*jsonPathError).FoundPath, "error FoundPath is correct")
-	a.Equal([]interface{}{true}, pathErr.(*jsonPathError).MissingPath, "error FoundPath is correct")
+	a.Equal([]interface{}{"a", 1}, pathErr.(*PathError).FoundPath, "error FoundPath is correct")
+	a.Equal([]interface{}{true}, pathErr.(*PathError).MissingPath, "error FoundPath is correct")
 
 	str, err := obj.ToString()
 	a.Nil(err, "err is nil")
 	a.Equal(`{"b":[[],{},{"c":"got it!"}]}`, str, "str is correct value")
 }
 
+func Test_Maybe(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`{"a":{"b":"hi","c":null}}`)
+	a.Nil(err, "err is nil")
+
+	a.Equal("hi", obj.Maybe("a").Maybe("b").StringOrDefault(""), "val is correct")
+	a.False(obj.Maybe("a", "b").IsNothing(), "val is not nothing")
+	a.False(obj.Maybe("a", "c").IsNothing(), "null is not nothing")
+
+	nothing := obj.Maybe("x").Maybe("y", 0)
+	a.True(nothing.IsNothing(), "val is nothing")
+	a.Equal("def", nothing.StringOrDefault("def"), "val is default")
+	a.Equal("def", nothing.StringOrDefault("def", "z"), "val is default")
+	_, err = nothing.Interface()
+	a.NotNil(err, "err is not nil")
+}
+
+func Test_Exists(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`{"a":{"b":"hi","c":null},"d":[null]}`)
+	a.Nil(err, "err is nil")
+
+	a.True(obj.Exists(), "root exists")
+	a.True(obj.Exists("a", "b"), "string exists")
+	a.True(obj.Exists("a", "c"), "null exists")
+	a.True(obj.Exists("d", 0), "null element exists")
+	a.False(obj.Exists("a", "e"), "missing key does not exist")
+	a.False(obj.Exists("d", 1), "missing index does not exist")
+	a.False(obj.Exists("a", "b", "c"), "path through string does not exist")
+
+	a.True(obj.IsNull("a", "c"), "null is null")
+	a.True(obj.IsNull("d", 0), "null element is null")
+	a.False(obj.IsNull("a", "b"), "string is not null")
+	a.False(obj.IsNull("a", "e"), "missing key is not null")
+	a.False(obj.Maybe("x").IsNull(), "nothing is not null")
+}
+
+func Test_Require(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`{"a":{"b":1,"c":null},"d":[0]}`)
+	a.Nil(err, "err is nil")
+
+	err = obj.Require([]interface{}{"a", "b"}, []interface{}{"d", 0})
+	a.Nil(err, "err is nil")
+	obj.MustRequire([]interface{}{"a"})
+
+	err = obj.Require([]interface{}{"a", "b"}, []interface{}{"a", "c"}, []interface{}{"d", 1}, []interface{}{"e.f"})
+	a.NotNil(err, "err is not nil")
+	a.Equal(`missing required paths: a.c, d.1, e\.f`, err.Error(), "error message is correct")
+}
+
 func Test_Set_WithMapKey(t *testing.T) {
 	a := assert.New(t)
 
@@ -316,8 +668,8 @@ func Test_Set_WithInappropriateMapKey(t *testing.T) {
 
 	pathErr := obj.Set("a", "b", true)
 	a.NotNil(pathErr, "err is not nil")
-	a.Equal([]interface{}{"a"}, pathErr.(*jsonPathError).FoundPath, "error FoundPath is correct")
-	a.Equal([]interface{}{"b"}, pathErr.(*jsonPathError).MissingPath, "error FoundPath is correct")
+	a.Equal([]interface{}{"a"}, pathErr.(*PathError).FoundPath, "error FoundPath is correct")
+	a.Equal([]interface{}{"b"}, pathErr.(*PathError).MissingPath, "error FoundPath is correct")
 
 	str, err := obj.ToString()
 	a.Nil(err, "err is nil")
@@ -344,16 +696,54 @@ func Test_Set_WithInappropriateSliceIndex(t *testing.T) {
 	obj, err := FromString(`{"a":[]}`)
 	a.Nil(err, "err is nil")
 
-	pathErr := obj.Set("a", 0, true)
+	pathErr := obj.Set("a", -1, true)
 	a.NotNil(pathErr, "err is not nil")
-	a.Equal([]interface{}{"a"}, pathErr.(*jsonPathError).FoundPath, "error FoundPath is correct")
-	a.Equal([]interface{}{0}, pathErr.(*jsonPathError).MissingPath, "error FoundPath is correct")
+	a.Equal([]interface{}{"a"}, pathErr.(*PathError).FoundPath, "error FoundPath is correct")
+	a.Equal([]interface{}{-1}, pathErr.(*PathError).MissingPath, "error FoundPath is correct")
 
 	str, err := obj.ToString()
 	a.Nil(err, "err is nil")
 	a.Equal(`{"a":[]}`, str, "str is correct value")
 }
 
+func Test_Set_CreatesSlices(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`{}`)
+	a.Nil(err, "err is nil")
+
+	err = obj.Set("a", 2, "x", true)
+	a.Nil(err, "err is nil")
+	a.Equal(`{"a":[null,null,{"x":true}]}`, obj.MustToString(), "slice is created and filled with nil")
+
+	obj.MustSet("a", 4, 1, "y")
+	a.Equal(`{"a":[null,null,{"x":true},null,[null,"y"]]}`, obj.MustToString(), "short slice is grown")
+
+	obj.MustSet("a", 0, 1)
+	a.Equal(`{"a":[1,null,{"x":true},null,[null,"y"]]}`, obj.MustToString(), "existing index is set")
+
+	root := MustFromString(`[]`)
+	root.MustSet(1, "b", 0, false)
+	a.Equal(`[null,{"b":[false]}]`, root.MustToString(), "root slice is grown")
+
+	pathErr := obj.Set("a", 0, "x", true)
+	a.Equal([]interface{}{"a", 0}, pathErr.(*PathError).FoundPath, "existing non container is not overwritten")
+	a.Equal([]interface{}{"x"}, pathErr.(*PathError).MissingPath, "error MissingPath is correct")
+	pathErr = obj.Set("a", 1, 0, true)
+	a.Equal([]interface{}{"a", 1}, pathErr.(*PathError).FoundPath, "existing nil is not overwritten")
+	pathErr = obj.Set("b", -1, true)
+	a.Equal([]interface{}{"b"}, pathErr.(*PathError).FoundPath, "negative index does not create a slice")
+	a.Equal([]interface{}{-1}, pathErr.(*PathError).MissingPath, "error MissingPath is correct")
+
+	short := MustFromString(`{"a":[1]}`)
+	pathErr = short.Set("a", 5, -1, true)
+	a.Equal([]interface{}{"a", 5}, pathErr.(*PathError).FoundPath, "error FoundPath is correct")
+	a.Equal(`{"a":[1]}`, short.MustToString(), "failed set does not grow slice")
+	pathErr = short.Set("b", "c", 1.5, true)
+	a.Equal([]interface{}{"b", "c"}, pathErr.(*PathError).FoundPath, "error FoundPath is correct")
+	a.Equal(`{"a":[1]}`, short.MustToString(), "failed set does not create containers")
+}
+
 func Test_Set_WithInappropriatePathValue(t *testing.T) {
 	a := assert.New(t)
 
@@ -362,14 +752,102 @@ func Test_Set_WithInappropriatePathValue(t *testing.T) {
 
 	pathErr := obj.Set( "a", true, true)
 	a.NotNil(pathErr, "err is not nil")
-	a.Equal([]interface{}{"a"}, pathErr.(*jsonPathError).FoundPath, "error FoundPath is correct")
-	a.Equal([]interface{}{true}, pathErr.(*jsonPathError).MissingPath, "error FoundPath is correct")
+	a.Equal([]interface{}{"a"}, pathErr.(*PathError).FoundPath, "error FoundPath is correct")
+	a.Equal([]interface{}{true}, pathErr.(*PathError).MissingPath, "error FoundPath is correct")
 
 	str, err := obj.ToString()
 	a.Nil(err, "err is nil")
 	a.Equal(`{"a":[]}`, str, "str is correct value")
 }
 
+func Test_SetArray(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`{"a":{"b":true}}`)
+	a.Nil(err, "err is nil")
+
+	err = obj.SetArray("a", "b")
+	a.Nil(err, "err is nil")
+	obj.MustSetArray("c")
+
+	str, err := obj.ToString()
+	a.Nil(err, "err is nil")
+	a.Equal(`{"a":{"b":[]},"c":[]}`, str, "str is correct value")
+}
+
+func Test_SetObject(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`[1]`)
+	a.Nil(err, "err is nil")
+
+	err = obj.SetObject(0)
+	a.Nil(err, "err is nil")
+	obj.MustSetObject(0, "b")
+
+	str, err := obj.ToString()
+	a.Nil(err, "err is nil")
+	a.Equal(`[{"b":{}}]`, str, "str is correct value")
+
+	err = obj.SetObject(-2)
+	a.NotNil(err, "err is not nil")
+}
+
+func Test_Append(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`{"a":[1],"b":{"c":[]},"d":"x"}`)
+	a.Nil(err, "err is nil")
+
+	err = obj.Append("a", 2)
+	a.Nil(err, "err is nil")
+	obj.MustAppend("b", "c", map[string]interface{}{"e": true})
+	a.Equal(`{"a":[1,2],"b":{"c":[{"e":true}]},"d":"x"}`, obj.MustToString(), "str is correct value")
+
+	root := MustFromString(`[]`)
+	root.MustAppend(1).MustAppend("two")
+	a.Equal(`[1,"two"]`, root.MustToString(), "root array is appended to")
+
+	err = obj.Append("d", 1)
+	a.Equal([]interface{}{"d"}, err.(*PathError).FoundPath, "error FoundPath is correct")
+	a.Equal([]interface{}{}, err.(*PathError).MissingPath, "error MissingPath is correct")
+	err = obj.Append("e", 1)
+	a.Equal([]interface{}{"e"}, err.(*PathError).MissingPath, "error MissingPath is correct")
+	err = obj.Append()
+	a.Equal("no value supplied", err.Error(), "error message is correct")
+}
+
+func Test_Insert(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`{"a":[1,3],"b":"x"}`)
+	a.Nil(err, "err is nil")
+
+	err = obj.Insert("a", 1, 2)
+	a.Nil(err, "err is nil")
+	obj.MustInsert("a", 0, 0).MustInsert("a", 4, 4)
+	a.Equal(`{"a":[0,1,2,3,4],"b":"x"}`, obj.MustToString(), "str is correct value")
+
+	root := MustFromString(`[]`)
+	root.MustInsert(0, "x").MustInsert(0, "w")
+	a.Equal(`["w","x"]`, root.MustToString(), "root array is inserted into")
+
+	err = obj.Insert("a", 6, 1)
+	a.Equal([]interface{}{"a"}, err.(*PathError).FoundPath, "error FoundPath is correct")
+	a.Equal([]interface{}{6}, err.(*PathError).MissingPath, "error MissingPath is correct")
+	err = obj.Insert("a", -1, 1)
+	a.Equal([]interface{}{-1}, err.(*PathError).MissingPath, "error MissingPath is correct")
+	err = obj.Insert("b", 0, 1)
+	a.Equal([]interface{}{"b"}, err.(*PathError).FoundPath, "error FoundPath is correct")
+	a.Equal([]interface{}{0}, err.(*PathError).MissingPath, "error MissingPath is correct")
+	err = obj.Insert("c", 0, 1)
+	a.Equal([]interface{}{"c"}, err.(*PathError).MissingPath, "error MissingPath is correct")
+	err = obj.Insert("a", "0", 1)
+	a.Equal("index must be an int", err.Error(), "error message is correct")
+	err = obj.Insert(1)
+	a.Equal("no index and value supplied", err.Error(), "error message is correct")
+}
+
 func Test_Del_WithMapKey(t *testing.T) {
 	a := assert.New(t)
 
@@ -450,8 +928,8 @@ func Test_Del_WithIncorrectPathValue(t *testing.T) {
 
 	pathErr := obj.Del("a", "c", "b")
 	a.NotNil(pathErr, "err is nil")
-	a.Equal([]interface{}{"a"}, pathErr.(*jsonPathError).FoundPath, "error FoundPath is correct")
-	a.Equal([]interface{}{"c", "b"}, pathErr.(*jsonPathError).MissingPath, "error FoundPath is correct")
+	a.Equal([]interface{}{"a"}, pathErr.(*PathError).FoundPath, "error FoundPath is correct")
+	a.Equal([]interface{}{"c", "b"}, pathErr.(*PathError).MissingPath, "error FoundPath is correct")
 
 	str, err := obj.ToString()
 	a.Nil(err, "err is nil")
@@ -466,8 +944,8 @@ func Test_Del_WithInappropriateLastMapKey(t *testing.T) {
 
 	pathErr := obj.Del("a", "b", "c", "d")
 	a.NotNil(pathErr, "err is nil")
-	a.Equal([]interface{}{"a", "b", "c"}, pathErr.(*jsonPathError).FoundPath, "error FoundPath is correct")
-	a.Equal([]interface{}{"d"}, pathErr.(*jsonPathError).MissingPath, "error FoundPath is correct")
+	a.Equal([]interface{}{"a", "b", "c"}, pathErr.(*PathError).FoundPath, "error FoundPath is correct")
+	a.Equal([]interface{}{"d"}, pathErr.(*PathError).MissingPath, "error FoundPath is correct")
 
 	str, err := obj.ToString()
 	a.Nil(err, "err is nil")
@@ -482,8 +960,8 @@ func Test_Del_WithInappropriateLastSliceIndex(t *testing.T) {
 
 	pathErr := obj.Del("a", "b", 1)
 	a.NotNil(pathErr, "err is nil")
-	a.Equal([]interface{}{"a", "b"}, pathErr.(*jsonPathError).FoundPath, "error FoundPath is correct")
-	a.Equal([]interface{}{1}, pathErr.(*jsonPathError).MissingPath, "error FoundPath is correct")
+	a.Equal([]interface{}{"a", "b"}, pathErr.(*PathError).FoundPath, "error FoundPath is correct")
+	a.Equal([]interface{}{1}, pathErr.(*PathError).MissingPath, "error FoundPath is correct")
 
 	str, err := obj.ToString()
 	a.Nil(err, "err is nil")
@@ -498,14 +976,40 @@ func Test_Del_WithOutOfBoundsLastSliceIndex(t *testing.T) {
 
 	pathErr := obj.Del("a", "b", "c", 1)
 	a.NotNil(pathErr, "err is nil")
-	a.Equal([]interface{}{"a", "b", "c"}, pathErr.(*jsonPathError).FoundPath, "error FoundPath is correct")
-	a.Equal([]interface{}{1}, pathErr.(*jsonPathError).MissingPath, "error FoundPath is correct")
+	a.Equal([]interface{}{"a", "b", "c"}, pathErr.(*PathError).FoundPath, "error FoundPath is correct")
+	a.Equal([]interface{}{1}, pathErr.(*PathError).MissingPath, "error FoundPath is correct")
 
 	str, err := obj.ToString()
 	a.Nil(err, "err is nil")
 	a.Equal(`{"a":{"b":{"c":["delete me!"]}}}`, str, "str is correct value")
 }
 
+func Test_NegativeSliceIndex(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`{"a":[1,[2,3],4]}`)
+	a.Nil(err, "err is nil")
+
+	a.Equal(4, obj.MustInt("a", -1), "-1 is the final element")
+	a.Equal(2, obj.MustInt("a", -2, 0), "negative index navigates")
+	_, err = obj.Get("a", -4)
+	a.Equal([]interface{}{"a"}, err.(*PathError).FoundPath, "error FoundPath is correct")
+	a.Equal([]interface{}{-4}, err.(*PathError).MissingPath, "error MissingPath is correct")
+
+	obj.MustSet("a", -1, 5)
+	obj.MustSet("a", -2, -1, 6)
+	a.Equal(`{"a":[1,[2,6],5]}`, obj.MustToString(), "str is correct value")
+	err = obj.Set("a", -4, 0)
+	a.Equal([]interface{}{-4}, err.(*PathError).MissingPath, "error MissingPath is correct")
+
+	obj.MustDel("a", -2, -2)
+	a.Equal(`{"a":[1,[6],5]}`, obj.MustToString(), "str is correct value")
+	obj.MustDel("a", -1)
+	a.Equal(`{"a":[1,[6]]}`, obj.MustToString(), "str is correct value")
+	err = obj.Del("a", -3)
+	a.Equal([]interface{}{-3}, err.(*PathError).MissingPath, "error MissingPath is correct")
+}
+
 func Test_Del_WithInappropriateLastPathValue(t *testing.T) {
 	a := assert.New(t)
 
@@ -514,8 +1018,8 @@ func Test_Del_WithInappropriateLastPathValue(t *testing.T) {
 
 	pathErr := obj.Del("a", "b", true)
 	a.NotNil(pathErr, "err is nil")
-	a.Equal([]interface{}{"a", "b"}, pathErr.(*jsonPathError).FoundPath, "error FoundPath is correct")
-	a.Equal([]interface{}{true}, pathErr.(*jsonPathError).MissingPath, "error FoundPath is correct")
+	a.Equal([]interface{}{"a", "b"}, pathErr.(*PathError).FoundPath, "error FoundPath is correct")
+	a.Equal([]interface{}{true}, pathErr.(*PathError).MissingPath, "error FoundPath is correct")
 	a.Equal("found: [a b] missing: [true]", pathErr.Error(), "error message is correct")
 
 	str, err := obj.ToString()
@@ -523,6 +1027,92 @@ func Test_Del_WithInappropriateLastPathValue(t *testing.T) {
 	a.Equal(`{"a":{"b":{"c":"delete me!"}}}`, str, "str is correct value")
 }
 
+func Test_Copy(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`{"a":{"b":[1,{"c":2}]},"d":[0]}`)
+	a.Nil(err, "err is nil")
+
+	err = obj.Copy([]interface{}{"a", "b"}, []interface{}{"e", "f"})
+	a.Nil(err, "err is nil")
+	a.Equal(`{"a":{"b":[1,{"c":2}]},"d":[0],"e":{"f":[1,{"c":2}]}}`, obj.MustToString(), "value is copied")
+	obj.MustSet("e", "f", 1, "c", 3)
+	a.Equal(2, obj.MustInt("a", "b", 1, "c"), "copy is deep")
+
+	obj.MustCopy([]interface{}{"a", "b", -1}, []interface{}{"d", 0})
+	a.Equal(2, obj.MustInt("d", 0, "c"), "value is copied into a slice")
+
+	err = obj.Copy([]interface{}{"a", "x"}, []interface{}{"g"})
+	a.Equal([]interface{}{"a"}, err.(*PathError).FoundPath, "from path error is returned")
+	a.Equal([]interface{}{"x"}, err.(*PathError).MissingPath, "from path error is returned")
+	before := obj.MustToString()
+	err = obj.Copy([]interface{}{"a"}, []interface{}{"a", "b", 0, "x"})
+	a.Equal([]interface{}{"a", "b", 0}, err.(*PathError).FoundPath, "to path error is returned")
+	a.Equal([]interface{}{"x"}, err.(*PathError).MissingPath, "to path error is returned")
+	a.Equal(before, obj.MustToString(), "document is untouched on error")
+	a.Panics(func() { obj.MustCopy([]interface{}{"x"}, []interface{}{"y"}) }, "panics on error")
+}
+
+func Test_Move(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`{"user":{"mail":"a@b.c","tags":["x","y"]}}`)
+	a.Nil(err, "err is nil")
+
+	err = obj.Move([]interface{}{"user", "mail"}, []interface{}{"user", "email"})
+	a.Nil(err, "err is nil")
+	a.Equal(`{"user":{"email":"a@b.c","tags":["x","y"]}}`, obj.MustToString(), "value is moved")
+
+	obj.MustMove([]interface{}{"user", "tags", 0}, []interface{}{"tag"})
+	a.Equal(`{"tag":"x","user":{"email":"a@b.c","tags":["y"]}}`, obj.MustToString(), "value is moved out of a slice")
+
+	obj.MustMove([]interface{}{"tag"}, []interface{}{"tag"})
+	a.Equal("x", obj.MustString("tag"), "moving to the same path does nothing")
+
+	err = obj.Move([]interface{}{"user"}, []interface{}{"user", "self"})
+	a.Equal("can not move [user] into itself at [user self]", err.Error(), "error message is correct")
+	err = obj.Move([]interface{}{"nope"}, []interface{}{"user"})
+	a.Equal([]interface{}{"nope"}, err.(*PathError).MissingPath, "from path error is returned")
+	before := obj.MustToString()
+	err = obj.Move([]interface{}{"tag"}, []interface{}{"user", "email", "x"})
+	a.Equal([]interface{}{"user", "email"}, err.(*PathError).FoundPath, "to path error is returned")
+	a.Equal(before, obj.MustToString(), "document is untouched on error")
+	a.Panics(func() { obj.MustMove([]interface{}{"x"}, []interface{}{"y"}) }, "panics on error")
+
+	nested := MustFromString(`{"a":{"b":{"b":1}}}`)
+	nested.MustMove([]interface{}{"a", "b"}, []interface{}{"a"})
+	a.Equal(`{"a":{"b":1}}`, nested.MustToString(), "value is moved onto its ancestor")
+}
+
+func Test_Pick(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`{"user":{"id":1,"name":"bob","pass":"x"},"items":[{"id":1,"n":2},{"id":2,"n":3}],"meta":{}}`)
+	a.Nil(err, "err is nil")
+
+	res, err := obj.Pick([]interface{}{"user", "name"}, []interface{}{"user", "id"}, []interface{}{"items", -1, "id"}, []interface{}{"missing", "x"}, []interface{}{"items", 5})
+	a.Nil(err, "err is nil")
+	a.Equal(`{"items":[null,{"id":2}],"user":{"id":1,"name":"bob"}}`, res.MustToString(), "only picked values are kept")
+	res, err = obj.Pick([]interface{}{"items", 1, "n"}, []interface{}{"items", 0, "n"})
+	a.Nil(err, "err is nil")
+	a.Equal(`{"items":[{"n":2},{"n":3}]}`, res.MustToString(), "paths are picked in any order")
+
+	res.MustSet("user", "name", "ann")
+	a.Equal("bob", obj.MustString("user", "name"), "picked values are copies")
+
+	a.Equal(`{}`, obj.MustPick().MustToString(), "no paths is empty")
+	a.Equal(`[{"n":3}]`, MustFromString(`[{"n":3,"x":1}]`).MustPick([]interface{}{0, "n"}).MustToString(), "root array is kept")
+
+	_, err = obj.Pick([]interface{}{}, []interface{}{"items", 0})
+	a.Nil(err, "err is nil")
+	_, err = MustFromString(`[1]`).Pick([]interface{}{}, []interface{}{0, "a"})
+	a.Nil(err, "err is nil")
+	_, err = MustFromString(`{"a":[1]}`).Pick([]interface{}{"a", 0}, []interface{}{})
+	a.Nil(err, "err is nil")
+	_, err = MustFromString(`"x"`).Pick([]interface{}{}, []interface{}{})
+	a.Nil(err, "err is nil")
+}
+
 func Test_Interface(t *testing.T) {
 	a := assert.New(t)
 
@@ -535,6 +1125,58 @@ func Test_Interface(t *testing.T) {
 	a.Equal(map[string]interface{}{"a": true}, val, "val is correct")
 }
 
+func Test_TypeError(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`{"a":{"b":true,"c":["x",1]},"d":[{},"y"]}`)
+	a.Nil(err, "err is nil")
+
+	var te *TypeError
+	_, err = obj.String("a", "b")
+	a.True(errors.As(err, &te), "err is a TypeError")
+	a.Equal(&TypeError{Path: []interface{}{"a", "b"}, Expected: "string", Actual: "bool"}, te, "err is correct")
+	a.Equal("type assertion to string failed", err.Error(), "error message is correct")
+
+	_, err = obj.Slice("a")
+	a.True(errors.As(err, &te), "err is a TypeError")
+	a.Equal("map[string]interface {}", te.Actual, "actual is the go type")
+	_, err = obj.Int("a", "b")
+	a.True(errors.As(err, &te), "err is a TypeError")
+	a.Equal("number", te.Expected, "expected is correct")
+
+	path := make([]interface{}, 2, 3)
+	path[0], path[1] = "a", "c"
+	_, err = obj.StringSlice(path...)
+	a.Equal("element 1: type assertion to string failed", err.Error(), "error message names the element")
+	a.True(errors.As(err, &te), "wrapped err is a TypeError")
+	a.Equal([]interface{}{"a", "c", 1}, te.Path, "path includes the element")
+	a.Equal(2, len(path), "callers path is not modified")
+	_, err = obj.MapSlice("d")
+	a.True(errors.As(err, &te), "wrapped err is a TypeError")
+	a.Equal(&TypeError{Path: []interface{}{"d", 1}, Expected: "map[string]interface{}", Actual: "string"}, te, "err is correct")
+
+	_, err = obj.IsEmptyContainer("a", "b")
+	a.True(errors.As(err, &te), "is empty container err is a TypeError")
+	err = obj.ForEach(func(key interface{}, value *Json) error { return nil }, "a", "b")
+	a.True(errors.As(err, &te), "for each err is a TypeError")
+	a.Equal([]interface{}{"a", "b"}, te.Path, "path is correct")
+	_, err = obj.Len("a", "b")
+	a.True(errors.As(err, &te), "len err is a TypeError")
+	_, err = obj.StringOrNumberString("a", "b")
+	a.True(errors.As(err, &te), "string or number string err is a TypeError")
+	_, err = obj.BoolOrBoolString("d")
+	a.True(errors.As(err, &te), "bool or bool string err is a TypeError")
+	_, err = MustNew().MustSet("n", -1).Uint("n")
+	a.True(errors.As(err, &te), "uint err is a TypeError")
+	a.Equal("uint", te.Expected, "expected is correct")
+	_, err = obj.Number("a", "c", 0)
+	a.True(errors.As(err, &te), "number err is a TypeError")
+	a.Equal("string", te.Actual, "actual is correct")
+
+	_, err = obj.Bool("x")
+	a.False(errors.As(err, &te), "path error is not a TypeError")
+}
+
 func Test_Map_PathError(t *testing.T) {
 	a := assert.New(t)
 
@@ -543,9 +1185,9 @@ func Test_Map_PathError(t *testing.T) {
 
 	val, err := obj.Map("a", "b")
 	a.NotNil(err, "err is not nil")
-	a.Equal([]interface{}{"a"}, err.(*jsonPathError).FoundPath, "error FoundPath is correct")
-	a.Equal([]interface{}{"b"}, err.(*jsonPathError).MissingPath, "error FoundPath is correct")
-	a.Equal("found: [a] missing: [b]", err.(*jsonPathError).Error(), "error message is correct")
+	a.Equal([]interface{}{"a"}, err.(*PathError).FoundPath, "error FoundPath is correct")
+	a.Equal([]interface{}{"b"}, err.(*PathError).MissingPath, "error FoundPath is correct")
+	a.Equal("found: [a] missing: [b]", err.(*PathError).Error(), "error message is correct")
 	a.Nil(val, "val is correct")
 }
 
@@ -567,9 +1209,9 @@ func Test_MapString_PathError(t *testing.T) {
 
 	val, err := obj.MapString("a", "b")
 	a.NotNil(err, "err is not nil")
-	a.Equal([]interface{}{"a"}, err.(*jsonPathError).FoundPath, "error FoundPath is correct")
-	a.Equal([]interface{}{"b"}, err.(*jsonPathError).MissingPath, "error FoundPath is correct")
-	a.Equal("found: [a] missing: [b]", err.(*jsonPathError).Error(), "error message is correct")
+	a.Equal([]interface{}{"a"}, err.(*PathError).FoundPath, "error FoundPath is correct")
+	a.Equal([]interface{}{"b"}, err.(*PathError).MissingPath, "error FoundPath is correct")
+	a.Equal("found: [a] missing: [b]", err.(*PathError).Error(), "error message is correct")
 	a.Nil(val, "val is correct")
 }
 
@@ -581,7 +1223,7 @@ func Test_MapString_ValueTypeError(t *testing.T) {
 
 	val, err := obj.MapString()
 	a.NotNil(err, "err is not nil")
-	a.Equal("type assertion of map value to string failed", err.Error(), "error message is correct")
+	a.Equal(`key "a": type assertion to string failed`, err.Error(), "error message is correct")
 	a.Nil(val, "val is correct")
 }
 
@@ -593,73 +1235,236 @@ func Test_MapString_MapTypeError(t *testing.T) {
 
 	val, err := obj.MapString()
 	a.NotNil(err, "err is not nil")
-	a.Equal("type assertion to map[string]string{} failed", err.Error(), "error message is correct")
+	a.Equal("type assertion to map[string]interface{} failed", err.Error(), "error message is correct")
 	a.Nil(val, "val is correct")
 }
 
-func Test_MustMapString(t *testing.T) {
+func Test_MustMapString(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`[{"a":"b"}]`)
+	a.Nil(err, "err is nil")
+
+	val := obj.MapStringOrDefault(nil, 0)
+	a.Equal(map[string]string{"a": "b"}, val, "val is correct")
+}
+
+func Test_MustMapString_Default(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`[{"a":"b"}]`)
+	a.Nil(err, "err is nil")
+
+	def := map[string]string{"c": "d"}
+	val := obj.MapStringOrDefault(def)
+	a.Equal(def, val, "val is correct")
+}
+
+func Test_MapStringSortedJSON(t *testing.T) {
+	a := assert.New(t)
+
+	obj := FromInterface(map[string]interface{}{"labels": map[string]interface{}{"z": "1", "a": "<2>", "m": "3"}, "bad": map[string]interface{}{"a": 1}})
+
+	str, err := obj.MapStringSortedJSON("labels")
+	a.Nil(err, "err is nil")
+	a.Equal(`{"a":"\u003c2\u003e","m":"3","z":"1"}`, str, "str is correct value")
+	a.Equal(str, obj.MustMapStringSortedJSON("labels"), "str is correct value")
+
+	str, err = obj.MapStringSortedJSON("bad")
+	a.NotNil(err, "err is not nil")
+	a.Equal("", str, "str is empty")
+}
+
+func Test_MustMap_DefaultValue(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`[]`)
+	a.Nil(err, "err is nil")
+
+	val := obj.MapOrDefault(map[string]interface{}{"a": true})
+	a.Equal(map[string]interface{}{"a": true}, val, "val is correct")
+}
+
+func Test_Keys(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`{"a":{"x":1,"y":2,"z":3},"b":[]}`)
+	a.Nil(err, "err is nil")
+
+	keys, err := obj.Keys("a")
+	a.Nil(err, "err is nil")
+	a.ElementsMatch([]string{"x", "y", "z"}, keys, "keys is correct")
+	a.ElementsMatch([]string{"a", "b"}, obj.MustKeys(), "keys is correct")
+
+	keys, err = obj.Keys("b")
+	a.Equal("type assertion to map[string]interface{} failed", err.Error(), "error message is correct")
+	a.Nil(keys, "keys is nil")
+	_, err = obj.Keys("c")
+	a.Equal([]interface{}{"c"}, err.(*PathError).MissingPath, "error MissingPath is correct")
+	a.Equal([]string{"def"}, obj.KeysOrDefault([]string{"def"}, "b"), "keys is default")
+}
+
+func Test_Slice_PathError(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`{"a":[true,false,true]}`)
+	a.Nil(err, "err is nil")
+
+	val, err := obj.Slice("a", "b")
+	a.NotNil(err, "err is not nil")
+	a.Equal([]interface{}{"a"}, err.(*PathError).FoundPath, "error FoundPath is correct")
+	a.Equal([]interface{}{"b"}, err.(*PathError).MissingPath, "error FoundPath is correct")
+	a.Equal("found: [a] missing: [b]", err.(*PathError).Error(), "error message is correct")
+	a.Nil(val, "val is nil")
+}
+
+func Test_MustSlice(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`{"a":[true,false,true]}`)
+	a.Nil(err, "err is nil")
+
+	val := obj.SliceOrDefault([]interface{}{}, "a")
+	a.Equal([]interface{}{true, false, true}, val, "val is correct")
+}
+
+func Test_MustSlice_DefaultValue(t *testing.T) {
 	a := assert.New(t)
 
-	obj, err := FromString(`[{"a":"b"}]`)
+	obj, err := FromString(`{}`)
 	a.Nil(err, "err is nil")
 
-	val := obj.MapStringOrDefault(nil, 0)
-	a.Equal(map[string]string{"a": "b"}, val, "val is correct")
+	val := obj.SliceOrDefault([]interface{}{true, false, true})
+	a.Equal([]interface{}{true, false, true}, val, "val is correct")
 }
 
-func Test_MustMapString_Default(t *testing.T) {
+func Test_MapSlice(t *testing.T) {
 	a := assert.New(t)
 
-	obj, err := FromString(`[{"a":"b"}]`)
+	obj, err := FromString(`{"a":[{"b":1},{}],"c":[{},1],"d":"x"}`)
 	a.Nil(err, "err is nil")
 
-	def := map[string]string{"c": "d"}
-	val := obj.MapStringOrDefault(def)
-	a.Equal(def, val, "val is correct")
+	val, err := obj.MapSlice("a")
+	a.Nil(err, "err is nil")
+	a.Len(val, 2, "val has correct length")
+	a.Equal(obj.MustInterface("a", 0, "b"), val[0]["b"], "val is correct")
+	a.Equal(val, obj.MustMapSlice("a"), "must val is correct")
+
+	val, err = obj.MapSlice("c")
+	a.Equal("element 1: type assertion to map[string]interface{} failed", err.Error(), "error message is correct")
+	a.Nil(val, "val is nil")
+	_, err = obj.MapSlice("d")
+	a.Equal("type assertion to []interface{} failed", err.Error(), "error message is correct")
+
+	def := []map[string]interface{}{{"z": true}}
+	a.Equal(def, obj.MapSliceOrDefault(def, "c"), "default is returned")
+	a.Equal(obj.MustMapSlice("a"), obj.MapSliceOrDefault(def, "a"), "val is returned")
 }
 
-func Test_MustMap_DefaultValue(t *testing.T) {
+func Test_JsonSlice(t *testing.T) {
 	a := assert.New(t)
 
-	obj, err := FromString(`[]`)
+	obj, err := FromString(`{"a":[{"b":[1,2]},"x"],"d":"x"}`)
 	a.Nil(err, "err is nil")
 
-	val := obj.MapOrDefault(map[string]interface{}{"a": true})
-	a.Equal(map[string]interface{}{"a": true}, val, "val is correct")
+	val, err := obj.JsonSlice("a")
+	a.Nil(err, "err is nil")
+	a.Len(val, 2, "val has correct length")
+	a.Equal(2, val[0].MustInt("b", 1), "elements support path access")
+	a.Equal("x", val[1].MustString(), "elements are wrapped")
+
+	val, err = obj.JsonSlice("d")
+	a.Equal("type assertion to []interface{} failed", err.Error(), "error message is correct")
+	a.Nil(val, "val is nil")
+
+	names := []string{}
+	for _, item := range obj.MustJsonSlice("a") {
+		names = append(names, item.StringOrDefault("none", "name"))
+	}
+	a.Equal([]string{"none", "none"}, names, "must val is iterable")
+
+	def := []*Json{FromInterface(1)}
+	a.Equal(def, obj.JsonSliceOrDefault(def, "d"), "default is returned")
+	a.Len(obj.JsonSliceOrDefault(def, "a"), 2, "val is returned")
 }
 
-func Test_Slice_PathError(t *testing.T) {
+func Test_IsEmptyContainer(t *testing.T) {
 	a := assert.New(t)
 
-	obj, err := FromString(`{"a":[true,false,true]}`)
+	obj, err := FromString(`{"a":{},"b":[],"c":{"d":1},"e":[null],"f":""}`)
 	a.Nil(err, "err is nil")
 
-	val, err := obj.Slice("a", "b")
+	a.True(obj.MustIsEmptyContainer("a"), "empty object is empty")
+	a.True(obj.MustIsEmptyContainer("b"), "empty array is empty")
+	a.False(obj.MustIsEmptyContainer("c"), "object is populated")
+	a.False(obj.MustIsEmptyContainer("e"), "array is populated")
+
+	val, err := obj.IsEmptyContainer("f")
+	a.Equal("type assertion to object or array failed", err.Error(), "error message is correct")
+	a.False(val, "val is false")
+	_, err = obj.IsEmptyContainer("g")
 	a.NotNil(err, "err is not nil")
-	a.Equal([]interface{}{"a"}, err.(*jsonPathError).FoundPath, "error FoundPath is correct")
-	a.Equal([]interface{}{"b"}, err.(*jsonPathError).MissingPath, "error FoundPath is correct")
-	a.Equal("found: [a] missing: [b]", err.(*jsonPathError).Error(), "error message is correct")
-	a.Nil(val, "val is nil")
 }
 
-func Test_MustSlice(t *testing.T) {
+func Test_ForEach(t *testing.T) {
 	a := assert.New(t)
 
-	obj, err := FromString(`{"a":[true,false,true]}`)
+	obj, err := FromString(`{"a":{"y":2,"x":1},"b":["p","q"],"c":true}`)
 	a.Nil(err, "err is nil")
 
-	val := obj.SliceOrDefault([]interface{}{}, "a")
-	a.Equal([]interface{}{true, false, true}, val, "val is correct")
+	keys, vals := []interface{}{}, []interface{}{}
+	err = obj.ForEach(func(key interface{}, value *Json) error {
+		keys = append(keys, key)
+		vals = append(vals, value.MustInt())
+		return nil
+	}, "a")
+	a.Nil(err, "err is nil")
+	a.Equal([]interface{}{"x", "y"}, keys, "object keys are sorted strings")
+	a.Equal([]interface{}{1, 2}, vals, "object values are correct")
+
+	keys, vals = []interface{}{}, []interface{}{}
+	obj.MustForEach(func(key interface{}, value *Json) error {
+		keys = append(keys, key)
+		vals = append(vals, value.MustString())
+		return nil
+	}, "b")
+	a.Equal([]interface{}{0, 1}, keys, "array keys are int indices")
+	a.Equal([]interface{}{"p", "q"}, vals, "array values are correct")
+
+	calls := 0
+	err = obj.ForEach(func(key interface{}, value *Json) error {
+		calls++
+		return errors.New("stop")
+	})
+	a.Equal("stop", err.Error(), "fn error is returned")
+	a.Equal(1, calls, "iteration stops at the first error")
+
+	err = obj.ForEach(func(interface{}, *Json) error { return nil }, "c")
+	a.Equal("type assertion to object or array failed", err.Error(), "error message is correct")
+	err = obj.ForEach(func(interface{}, *Json) error { return nil }, "d")
+	a.Equal([]interface{}{"d"}, err.(*PathError).MissingPath, "error MissingPath is correct")
 }
 
-func Test_MustSlice_DefaultValue(t *testing.T) {
+func Test_Len(t *testing.T) {
 	a := assert.New(t)
 
-	obj, err := FromString(`{}`)
+	obj, err := FromString(`{"a":{"x":1,"y":2},"b":[1,2,3],"c":"héllo","d":true,"e":null}`)
 	a.Nil(err, "err is nil")
 
-	val := obj.SliceOrDefault([]interface{}{true, false, true})
-	a.Equal([]interface{}{true, false, true}, val, "val is correct")
+	a.Equal(2, obj.MustLen("a"), "object length is correct")
+	a.Equal(3, obj.MustLen("b"), "array length is correct")
+	a.Equal(5, obj.MustLen("c"), "string length counts runes")
+	a.Equal(5, obj.MustLen(), "root length is correct")
+
+	val, err := obj.Len("d")
+	a.Equal("type assertion to array, object or string failed", err.Error(), "error message is correct")
+	a.Equal(0, val, "val is 0")
+	_, err = obj.Len("e")
+	a.NotNil(err, "err is not nil")
+
+	a.Equal(-1, obj.LenOrDefault(-1, "d"), "default is returned")
+	a.Equal(-1, obj.LenOrDefault(-1, "f"), "default is returned")
+	a.Equal(3, obj.LenOrDefault(-1, "b"), "length is returned")
 }
 
 func Test_Bool(t *testing.T) {
@@ -681,9 +1486,9 @@ func Test_Bool_PathError(t *testing.T) {
 
 	val, err := obj.Bool("a", "b")
 	a.NotNil(err, "err is not nil")
-	a.Equal([]interface{}{"a"}, err.(*jsonPathError).FoundPath, "error FoundPath is correct")
-	a.Equal([]interface{}{"b"}, err.(*jsonPathError).MissingPath, "error FoundPath is correct")
-	a.Equal("found: [a] missing: [b]", err.(*jsonPathError).Error(), "error message is correct")
+	a.Equal([]interface{}{"a"}, err.(*PathError).FoundPath, "error FoundPath is correct")
+	a.Equal([]interface{}{"b"}, err.(*PathError).MissingPath, "error FoundPath is correct")
+	a.Equal("found: [a] missing: [b]", err.(*PathError).Error(), "error message is correct")
 	a.Equal(false, val, "val is correct")
 }
 
@@ -737,9 +1542,9 @@ func Test_String_PathError(t *testing.T) {
 
 	val, err := obj.String("a", "b")
 	a.NotNil(err, "err is not nil")
-	a.Equal([]interface{}{"a"}, err.(*jsonPathError).FoundPath, "error FoundPath is correct")
-	a.Equal([]interface{}{"b"}, err.(*jsonPathError).MissingPath, "error FoundPath is correct")
-	a.Equal("found: [a] missing: [b]", err.(*jsonPathError).Error(), "error message is correct")
+	a.Equal([]interface{}{"a"}, err.(*PathError).FoundPath, "error FoundPath is correct")
+	a.Equal([]interface{}{"b"}, err.(*PathError).MissingPath, "error FoundPath is correct")
+	a.Equal("found: [a] missing: [b]", err.(*PathError).Error(), "error message is correct")
 	a.Equal("", val, "val is correct")
 }
 
@@ -753,6 +1558,43 @@ func Test_MustString_DefaultValue(t *testing.T) {
 	a.Equal("hi", val, "val is correct")
 }
 
+func Test_StringOrNumberString(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`{"a":"123","b":123,"c":1.50,"d":true}`)
+	a.Nil(err, "err is nil")
+	obj.MustSet("e", 7)
+
+	a.Equal("123", obj.MustStringOrNumberString("a"), "string is returned as is")
+	a.Equal("123", obj.MustStringOrNumberString("b"), "number is returned as a string")
+	a.Equal("1.50", obj.MustStringOrNumberString("c"), "number text is preserved")
+	a.Equal("7", obj.MustStringOrNumberString("e"), "go int is returned as a string")
+
+	val, err := obj.StringOrNumberString("d")
+	a.Equal("type assertion to string or number failed", err.Error(), "error message is correct")
+	a.Equal("", val, "val is empty")
+	a.Equal("def", obj.StringOrNumberStringOrDefault("def", "d"), "default is returned")
+	a.Equal("def", obj.StringOrNumberStringOrDefault("def", "f"), "default is returned")
+}
+
+func Test_BoolOrBoolString(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`{"a":true,"b":"false","c":"1","d":"yes","e":1}`)
+	a.Nil(err, "err is nil")
+
+	a.True(obj.MustBoolOrBoolString("a"), "bool is returned as is")
+	a.False(obj.MustBoolOrBoolString("b"), "bool string is parsed")
+	a.True(obj.MustBoolOrBoolString("c"), "numeric bool string is parsed")
+
+	_, err = obj.BoolOrBoolString("d")
+	a.NotNil(err, "err is not nil")
+	_, err = obj.BoolOrBoolString("e")
+	a.Equal("type assertion to bool or bool string failed", err.Error(), "error message is correct")
+	a.True(obj.BoolOrBoolStringOrDefault(true, "d"), "default is returned")
+	a.True(obj.BoolOrBoolStringOrDefault(true, "f"), "default is returned")
+}
+
 func Test_StringSlice(t *testing.T) {
 	a := assert.New(t)
 
@@ -839,9 +1681,9 @@ func Test_Time_PathError(t *testing.T) {
 
 	val, err := obj.Time("a", "b")
 	a.NotNil(err, "err is not nil")
-	a.Equal([]interface{}{"a"}, err.(*jsonPathError).FoundPath, "error FoundPath is correct")
-	a.Equal([]interface{}{"b"}, err.(*jsonPathError).MissingPath, "error FoundPath is correct")
-	a.Equal("found: [a] missing: [b]", err.(*jsonPathError).Error(), "error message is correct")
+	a.Equal([]interface{}{"a"}, err.(*PathError).FoundPath, "error FoundPath is correct")
+	a.Equal([]interface{}{"b"}, err.(*PathError).MissingPath, "error FoundPath is correct")
+	a.Equal("found: [a] missing: [b]", err.(*PathError).Error(), "error message is correct")
 	a.True(val.IsZero(), "val is correct")
 }
 
@@ -866,6 +1708,66 @@ func Test_MustTime_DefaultValue(t *testing.T) {
 	a.Equal(now, val, "val is correct")
 }
 
+func Test_TimeWithLayout(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`{"a":"2020-03-04","b":"2020-03-04T05:06:07Z","c":"04/03/2020","d":1,"e":["2020-03-04","2021-01-02T00:00:00Z"],"f":["x"]}`)
+	a.Nil(err, "err is nil")
+	now := time.Now()
+	obj.MustSet("g", now)
+
+	val, err := obj.TimeWithLayout("2006-01-02", "a")
+	a.Nil(err, "err is nil")
+	a.Equal(time.Date(2020, 3, 4, 0, 0, 0, 0, time.UTC), val, "layout is used")
+	a.Equal(time.Date(2020, 3, 4, 5, 6, 7, 0, time.UTC), obj.MustTimeWithLayout("2006-01-02", "b"), "falls back to RFC 3339")
+	a.Equal(now, obj.MustTimeWithLayout("2006-01-02", "g"), "time.Time is returned as is")
+	a.Equal(time.Date(2020, 3, 4, 5, 6, 7, 0, time.UTC), obj.MustTimeWithLayout("", "b"), "empty layout is RFC 3339")
+
+	_, err = obj.TimeWithLayout("2006-01-02", "c")
+	a.Equal("type assertion to time.Time failed", err.Error(), "error message is correct")
+	_, err = obj.TimeWithLayout("2006-01-02", "d")
+	a.NotNil(err, "err is not nil")
+
+	vals, err := obj.TimeSliceWithLayout("2006-01-02", "e")
+	a.Nil(err, "err is nil")
+	a.Equal([]time.Time{time.Date(2020, 3, 4, 0, 0, 0, 0, time.UTC), time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)}, vals, "vals are correct")
+	a.Equal(vals, obj.MustTimeSliceWithLayout("2006-01-02", "e"), "must vals are correct")
+	_, err = obj.TimeSliceWithLayout("2006-01-02", "f")
+	a.NotNil(err, "err is not nil")
+}
+
+func Test_TimeUnix(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`{"a":1583298367,"b":1583298367.5,"c":"1583298367","d":"x","e":true,"f":1583298367123}`)
+	a.Nil(err, "err is nil")
+	obj.MustSet("g", int64(-1))
+
+	val, err := obj.TimeUnix("a")
+	a.Nil(err, "err is nil")
+	a.Equal(time.Date(2020, 3, 4, 5, 6, 7, 0, time.UTC), val, "val is correct")
+	a.Equal(time.Date(2020, 3, 4, 5, 6, 7, 500000000, time.UTC), obj.MustTimeUnix("b"), "fractional seconds are kept")
+	a.Equal(time.Date(2020, 3, 4, 5, 6, 7, 0, time.UTC), obj.MustTimeUnix("c"), "numeric string is accepted")
+	a.Equal(time.Date(1969, 12, 31, 23, 59, 59, 0, time.UTC), obj.MustTimeUnix("g"), "negative value is before the epoch")
+
+	_, err = obj.TimeUnix("d")
+	a.Equal("type assertion to number failed", err.Error(), "error message is correct")
+	_, err = obj.TimeUnix("e")
+	a.NotNil(err, "err is not nil")
+	def := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	a.Equal(def, obj.TimeUnixOrDefault(def, "d"), "default is returned")
+	a.Equal(val, obj.TimeUnixOrDefault(def, "a"), "val is returned")
+
+	val, err = obj.TimeUnixMilli("f")
+	a.Nil(err, "err is nil")
+	a.Equal(time.Date(2020, 3, 4, 5, 6, 7, 123000000, time.UTC), val, "val is correct")
+	a.Equal(val, obj.MustTimeUnixMilli("f"), "must val is correct")
+	_, err = obj.TimeUnixMilli("d")
+	a.NotNil(err, "err is not nil")
+	a.Equal(def, obj.TimeUnixMilliOrDefault(def, "e"), "default is returned")
+	a.Equal(val, obj.TimeUnixMilliOrDefault(def, "f"), "val is returned")
+}
+
 func Test_TimeSlice(t *testing.T) {
 	a := assert.New(t)
 
@@ -920,6 +1822,36 @@ func Test_MustTimeSlice_DefaultValue(t *testing.T) {
 	a.Equal(def, val, "val is correct")
 }
 
+func Test_WithTimeLayout(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`{"a":{"b":"2019-02-27"},"c":["2019-02-28","2019-03-01T00:00:00Z"]}`)
+	a.Nil(err, "err is nil")
+
+	_, err = obj.Time("a", "b")
+	a.NotNil(err, "err is not nil")
+
+	view := obj.WithTimeLayout("2006-01-02")
+	val, err := view.Time("a", "b")
+	a.Nil(err, "err is nil")
+	a.Equal(time.Date(2019, 2, 27, 0, 0, 0, 0, time.UTC), val, "val is correct")
+
+	val, err = view.MustGet("a").Time("b")
+	a.Nil(err, "err is nil")
+	a.Equal(time.Date(2019, 2, 27, 0, 0, 0, 0, time.UTC), val, "val from sub Json is correct")
+
+	vals, err := view.TimeSlice("c")
+	a.Nil(err, "err is nil")
+	a.Equal([]time.Time{time.Date(2019, 2, 28, 0, 0, 0, 0, time.UTC), time.Date(2019, 3, 1, 0, 0, 0, 0, time.UTC)}, vals, "vals is correct")
+
+	view.MustSet("a", "b", "shared")
+	a.Equal("shared", obj.MustString("a", "b"), "view shares data")
+
+	a.True(obj.Maybe("x").WithTimeLayout("2006-01-02").IsNothing(), "view keeps nothing")
+	ordered := MustFromBytesKeepOrder([]byte(`{"z":1,"a":2}`))
+	a.Equal(`{"z":1,"a":2}`, ordered.WithTimeLayout("2006-01-02").MustToString(), "view keeps key order")
+}
+
 func Test_Duration(t *testing.T) {
 	a := assert.New(t)
 
@@ -931,6 +1863,32 @@ func Test_Duration(t *testing.T) {
 	a.Equal(time.Second, val, "val is correct")
 }
 
+func Test_Duration_Nanoseconds(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`{"a":1000000000,"b":1.5,"c":true,"d":"1000000000"}`)
+	a.Nil(err, "err is nil")
+	obj.MustSet("e", 2*time.Minute)
+	obj.MustSet("f", int32(500))
+
+	val, err := obj.Duration("a")
+	a.Nil(err, "err is nil")
+	a.Equal(time.Second, val, "number is nanoseconds")
+	a.Equal(2*time.Minute, obj.MustDuration("e"), "time.Duration is returned as is")
+	a.Equal(500*time.Nanosecond, obj.MustDuration("f"), "go int is nanoseconds")
+
+	_, err = obj.Duration("b")
+	a.Equal("value 1.5 is not an integer count of nanoseconds", err.Error(), "error message is correct")
+	_, err = obj.Duration("c")
+	a.Equal("type assertion to string or number failed", err.Error(), "error message is correct")
+	_, err = obj.Duration("d")
+	a.NotNil(err, "numeric string is not a duration string")
+
+	slice, err := FromString(`["1s",1000000000,"500ms"]`)
+	a.Nil(err, "err is nil")
+	a.Equal([]time.Duration{time.Second, time.Second, 500 * time.Millisecond}, slice.MustDurationSlice(), "slice mixes strings and numbers")
+}
+
 func Test_Duration_Error(t *testing.T) {
 	a := assert.New(t)
 
@@ -1022,6 +1980,83 @@ func Test_MustDurationSlice_Error(t *testing.T) {
 	a.Equal([]time.Duration{5 * time.Second}, val, "val is correct")
 }
 
+func Test_Bytes(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`{"a":"aGVsbG8=","b":"not base64!","c":1}`)
+	a.Nil(err, "err is nil")
+
+	val, err := obj.Bytes("a")
+	a.Nil(err, "err is nil")
+	a.Equal([]byte("hello"), val, "val is correct")
+	a.Equal([]byte("hello"), obj.MustBytes("a"), "must val is correct")
+
+	_, err = obj.Bytes("b")
+	a.NotNil(err, "err is not nil")
+	_, err = obj.Bytes("c")
+	a.Equal("type assertion to string failed", err.Error(), "error message is correct")
+	a.Equal([]byte("def"), obj.BytesOrDefault([]byte("def"), "b"), "default is returned")
+
+	err = obj.SetBytes("d", "e", []byte{0, 1, 254, 255})
+	a.Nil(err, "err is nil")
+	a.Equal("AAH+/w==", obj.MustString("d", "e"), "bytes are stored as base64")
+	a.Equal([]byte{0, 1, 254, 255}, obj.MustSetBytes("f", []byte{}).MustBytes("d", "e"), "bytes round trip")
+	a.Equal("", obj.MustString("f"), "empty bytes are an empty string")
+
+	err = obj.SetBytes("g", "x")
+	a.Equal("value must be a []byte", err.Error(), "error message is correct")
+	err = obj.SetBytes()
+	a.Equal("no value supplied", err.Error(), "error message is correct")
+}
+
+func Test_ByteSize(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`{"a":"10MB","b":"1.5GiB","c":"512k","d":" 2 KiB ","e":"7","f":1024,"g":"1kb"}`)
+	a.Nil(err, "err is nil")
+
+	a.Equal(int64(10000000), obj.MustByteSize("a"), "val is correct")
+	a.Equal(int64(1610612736), obj.MustByteSize("b"), "val is correct")
+	a.Equal(int64(512000), obj.MustByteSize("c"), "val is correct")
+	a.Equal(int64(2048), obj.MustByteSize("d"), "val is correct")
+	a.Equal(int64(7), obj.MustByteSize("e"), "val is correct")
+	a.Equal(int64(1024), obj.MustByteSize("f"), "val is correct")
+	a.Equal(int64(1000), obj.MustByteSize("g"), "val is correct")
+}
+
+func Test_ByteSize_Error(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`{"a":"10XB","b":"1.5B","c":true}`)
+	a.Nil(err, "err is nil")
+
+	_, err = obj.ByteSize("a")
+	a.Equal(`invalid byte size "10XB"`, err.Error(), "error message is correct")
+	_, err = obj.ByteSize("b")
+	a.Equal(`byte size "1.5B" is not a whole number of bytes that fits in an int64`, err.Error(), "error message is correct")
+	a.Equal(int64(5), obj.ByteSizeOrDefault(5, "c"), "val is default")
+}
+
+func Test_SetByteSize(t *testing.T) {
+	a := assert.New(t)
+
+	obj := MustNew()
+	obj.MustSetByteSize("a", int64(10<<20))
+	obj.MustSetByteSize("b", int64(3000))
+	obj.MustSetByteSize("c", int64(1023))
+	obj.MustSetByteSize("d", 1024)
+	obj.MustSetByteSize("e", uint32(1<<30))
+	a.Equal("value must be a whole number count of bytes that fits in an int64", obj.SetByteSize("f", 1.5).Error(), "error message is correct")
+	a.NotNil(obj.SetByteSize("f", "1024"), "err is not nil")
+	a.NotNil(obj.SetByteSize("f", uint64(1<<63)), "err is not nil")
+
+	str, err := obj.ToString()
+	a.Nil(err, "err is nil")
+	a.Equal(`{"a":"10MiB","b":"3kB","c":"1023B","d":"1KiB","e":"1GiB"}`, str, "str is correct value")
+	a.Equal(int64(10<<20), obj.MustByteSize("a"), "val round trips")
+	a.Equal(int64(3000), obj.MustByteSize("b"), "val round trips")
+}
+
 func Test_Int(t *testing.T) {
 	a := assert.New(t)
 
@@ -1036,7 +2071,7 @@ func Test_Int(t *testing.T) {
 func Test_Int_WithAFloat(t *testing.T) {
 	a := assert.New(t)
 
-	obj := &Json{42.3}
+	obj := &Json{data: 42.3}
 
 	val, err := obj.Int()
 	a.Nil(err, "err is nil")
@@ -1057,7 +2092,7 @@ func Test_Int_WithAJsonFloat(t *testing.T) {
 func Test_Int_WithAnInt(t *testing.T) {
 	a := assert.New(t)
 
-	obj := &Json{42}
+	obj := &Json{data: 42}
 
 	val, err := obj.Int()
 	a.Nil(err, "err is nil")
@@ -1067,7 +2102,7 @@ func Test_Int_WithAnInt(t *testing.T) {
 func Test_Int_WithAUint(t *testing.T) {
 	a := assert.New(t)
 
-	obj := &Json{uint(42)}
+	obj := &Json{data: uint(42)}
 
 	val, err := obj.Int()
 	a.Nil(err, "err is nil")
@@ -1077,7 +2112,7 @@ func Test_Int_WithAUint(t *testing.T) {
 func Test_Int_Error(t *testing.T) {
 	a := assert.New(t)
 
-	obj := &Json{"hi"}
+	obj := &Json{data: "hi"}
 
 	val, err := obj.Int()
 	a.NotNil(err, "err is not nil")
@@ -1087,7 +2122,7 @@ func Test_Int_Error(t *testing.T) {
 func Test_MustInt(t *testing.T) {
 	a := assert.New(t)
 
-	obj := &Json{42}
+	obj := &Json{data: 42}
 
 	val := obj.IntOrDefault(24)
 	a.Equal(42, val, "val is correct")
@@ -1096,7 +2131,7 @@ func Test_MustInt(t *testing.T) {
 func Test_MustInt_DefaultValue(t *testing.T) {
 	a := assert.New(t)
 
-	obj := &Json{"hi"}
+	obj := &Json{data: "hi"}
 
 	val := obj.IntOrDefault(24)
 	a.Equal(24, val, "val is correct")
@@ -1155,6 +2190,30 @@ func Test_MustIntSlice_DefaultValue(t *testing.T) {
 	a.Equal([]int{0, 1, 2}, val, "val is correct")
 }
 
+func Test_IntSliceStrict(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`[0,"1",2.0,-3]`)
+	a.Nil(err, "err is nil")
+
+	val, err := obj.IntSliceStrict()
+	a.Nil(err, "err is nil")
+	a.Equal([]int{0, 1, 2, -3}, val, "val is correct")
+	a.Equal([]int{0, 1, 2, -3}, obj.MustIntSliceStrict(), "val is correct")
+}
+
+func Test_IntSliceStrict_NotExact(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`[0,1.5]`)
+	a.Nil(err, "err is nil")
+
+	val, err := obj.IntSliceStrict()
+	a.Equal("element 1 value 1.5 does not convert exactly to int", err.Error(), "error message is correct")
+	a.Nil(val, "val is nil")
+	a.Equal([]int{7}, obj.IntSliceStrictOrDefault([]int{7}), "val is default")
+}
+
 func Test_Float64_PathError(t *testing.T) {
 	a := assert.New(t)
 
@@ -1162,16 +2221,16 @@ func Test_Float64_PathError(t *testing.T) {
 
 	val, err := obj.Float64("a", "b")
 	a.NotNil(err, "err is not nil")
-	a.Equal([]interface{}{"a"}, err.(*jsonPathError).FoundPath, "error FoundPath is correct")
-	a.Equal([]interface{}{"b"}, err.(*jsonPathError).MissingPath, "error FoundPath is correct")
-	a.Equal("found: [a] missing: [b]", err.(*jsonPathError).Error(), "error message is correct")
+	a.Equal([]interface{}{"a"}, err.(*PathError).FoundPath, "error FoundPath is correct")
+	a.Equal([]interface{}{"b"}, err.(*PathError).MissingPath, "error FoundPath is correct")
+	a.Equal("found: [a] missing: [b]", err.(*PathError).Error(), "error message is correct")
 	a.Equal(float64(0), val, "val is correct")
 }
 
 func Test_MustFloat64(t *testing.T) {
 	a := assert.New(t)
 
-	obj := &Json{42}
+	obj := &Json{data: 42}
 
 	val := obj.Float64OrDefault(24)
 	a.Equal(42.0, val, "val is correct")
@@ -1180,7 +2239,7 @@ func Test_MustFloat64(t *testing.T) {
 func Test_MustFloat64_DefaultValue(t *testing.T) {
 	a := assert.New(t)
 
-	obj := &Json{"hi"}
+	obj := &Json{data: "hi"}
 
 	val := obj.Float64OrDefault(24)
 	a.Equal(24.0, val, "val is correct")
@@ -1258,16 +2317,16 @@ func Test_Int64_PathError(t *testing.T) {
 
 	val, err := obj.Int64("a", "b")
 	a.NotNil(err, "err is not nil")
-	a.Equal([]interface{}{"a"}, err.(*jsonPathError).FoundPath, "error FoundPath is correct")
-	a.Equal([]interface{}{"b"}, err.(*jsonPathError).MissingPath, "error FoundPath is correct")
-	a.Equal("found: [a] missing: [b]", err.(*jsonPathError).Error(), "error message is correct")
+	a.Equal([]interface{}{"a"}, err.(*PathError).FoundPath, "error FoundPath is correct")
+	a.Equal([]interface{}{"b"}, err.(*PathError).MissingPath, "error FoundPath is correct")
+	a.Equal("found: [a] missing: [b]", err.(*PathError).Error(), "error message is correct")
 	a.Equal(int64(0), val, "val is correct")
 }
 
 func Test_Int64_WithAFloat(t *testing.T) {
 	a := assert.New(t)
 
-	obj := &Json{42.3}
+	obj := &Json{data: 42.3}
 
 	val, err := obj.Int64()
 	a.Nil(err, "err is nil")
@@ -1288,7 +2347,7 @@ func Test_Int64_WithAJsonFloat(t *testing.T) {
 func Test_Int64_WithAnInt64(t *testing.T) {
 	a := assert.New(t)
 
-	obj := &Json{42}
+	obj := &Json{data: 42}
 
 	val, err := obj.Int64()
 	a.Nil(err, "err is nil")
@@ -1298,7 +2357,7 @@ func Test_Int64_WithAnInt64(t *testing.T) {
 func Test_Int64_WithAUint64(t *testing.T) {
 	a := assert.New(t)
 
-	obj := &Json{uint64(42)}
+	obj := &Json{data: uint64(42)}
 
 	val, err := obj.Int64()
 	a.Nil(err, "err is nil")
@@ -1308,7 +2367,7 @@ func Test_Int64_WithAUint64(t *testing.T) {
 func Test_Int64_Error(t *testing.T) {
 	a := assert.New(t)
 
-	obj := &Json{"hi"}
+	obj := &Json{data: "hi"}
 
 	val, err := obj.Int64()
 	a.NotNil(err, "err is not nil")
@@ -1318,7 +2377,7 @@ func Test_Int64_Error(t *testing.T) {
 func Test_MustInt64(t *testing.T) {
 	a := assert.New(t)
 
-	obj := &Json{42}
+	obj := &Json{data: 42}
 
 	val := obj.Int64OrDefault(24)
 	a.Equal(int64(42), val, "val is correct")
@@ -1327,7 +2386,7 @@ func Test_MustInt64(t *testing.T) {
 func Test_MustInt64_DefaultValue(t *testing.T) {
 	a := assert.New(t)
 
-	obj := &Json{"hi"}
+	obj := &Json{data: "hi"}
 
 	val := obj.Int64OrDefault(24)
 	a.Equal(int64(24), val, "val is correct")
@@ -1386,6 +2445,29 @@ func Test_MustInt64Slice_DefaultValue(t *testing.T) {
 	a.Equal([]int64{0, 1, 2}, val, "val is correct")
 }
 
+func Test_Int64SliceStrict(t *testing.T) {
+	a := assert.New(t)
+
+	obj := FromInterface([]interface{}{json.Number("9223372036854775807"), float32(2), uint8(3)})
+
+	val, err := obj.Int64SliceStrict()
+	a.Nil(err, "err is nil")
+	a.Equal([]int64{9223372036854775807, 2, 3}, val, "val is correct")
+	a.Equal(val, obj.MustInt64SliceStrict(), "val is correct")
+}
+
+func Test_Int64SliceStrict_Overflow(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`[1,9223372036854775808]`)
+	a.Nil(err, "err is nil")
+
+	val, err := obj.Int64SliceStrict()
+	a.Equal("element 1 value 9223372036854775808 does not convert exactly to int64", err.Error(), "error message is correct")
+	a.Nil(val, "val is nil")
+	a.Nil(obj.Int64SliceStrictOrDefault(nil), "val is default")
+}
+
 func Test_Uint64(t *testing.T) {
 	a := assert.New(t)
 
@@ -1405,16 +2487,16 @@ func Test_Uint64_PathError(t *testing.T) {
 
 	val, err := obj.Uint64("a", "b")
 	a.NotNil(err, "err is not nil")
-	a.Equal([]interface{}{"a"}, err.(*jsonPathError).FoundPath, "error FoundPath is correct")
-	a.Equal([]interface{}{"b"}, err.(*jsonPathError).MissingPath, "error FoundPath is correct")
-	a.Equal("found: [a] missing: [b]", err.(*jsonPathError).Error(), "error message is correct")
+	a.Equal([]interface{}{"a"}, err.(*PathError).FoundPath, "error FoundPath is correct")
+	a.Equal([]interface{}{"b"}, err.(*PathError).MissingPath, "error FoundPath is correct")
+	a.Equal("found: [a] missing: [b]", err.(*PathError).Error(), "error message is correct")
 	a.Equal(uint64(0), val, "val is correct")
 }
 
 func Test_Uint64_WithAFloat(t *testing.T) {
 	a := assert.New(t)
 
-	obj := &Json{42.3}
+	obj := &Json{data: 42.3}
 
 	val, err := obj.Uint64()
 	a.Nil(err, "err is nil")
@@ -1435,7 +2517,7 @@ func Test_Uint64_WithAJsonFloat(t *testing.T) {
 func Test_Uint64_WithAnUint64(t *testing.T) {
 	a := assert.New(t)
 
-	obj := &Json{42}
+	obj := &Json{data: 42}
 
 	val, err := obj.Uint64()
 	a.Nil(err, "err is nil")
@@ -1445,7 +2527,7 @@ func Test_Uint64_WithAnUint64(t *testing.T) {
 func Test_Uint64_WithAUuint64(t *testing.T) {
 	a := assert.New(t)
 
-	obj := &Json{uint64(42)}
+	obj := &Json{data: uint64(42)}
 
 	val, err := obj.Uint64()
 	a.Nil(err, "err is nil")
@@ -1455,7 +2537,7 @@ func Test_Uint64_WithAUuint64(t *testing.T) {
 func Test_Uint64_Error(t *testing.T) {
 	a := assert.New(t)
 
-	obj := &Json{"hi"}
+	obj := &Json{data: "hi"}
 
 	val, err := obj.Uint64()
 	a.NotNil(err, "err is not nil")
@@ -1465,7 +2547,7 @@ func Test_Uint64_Error(t *testing.T) {
 func Test_MustUint64(t *testing.T) {
 	a := assert.New(t)
 
-	obj := &Json{42}
+	obj := &Json{data: 42}
 
 	val := obj.Uint64OrDefault(24)
 	a.Equal(uint64(42), val, "val is correct")
@@ -1474,7 +2556,7 @@ func Test_MustUint64(t *testing.T) {
 func Test_MustUint64_DefaultValue(t *testing.T) {
 	a := assert.New(t)
 
-	obj := &Json{"hi"}
+	obj := &Json{data: "hi"}
 
 	val := obj.Uint64OrDefault(24)
 	a.Equal(uint64(24), val, "val is correct")
@@ -1532,3 +2614,226 @@ func Test_MustUint64Slice_DefaultValue(t *testing.T) {
 	val := obj.Uint64SliceOrDefault([]uint64{0, 1, 2})
 	a.Equal([]uint64{0, 1, 2}, val, "val is correct")
 }
+
+func Test_Uint(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`{"a":5,"b":"6","d":-1,"f":"x"}`)
+	a.Nil(err, "err is nil")
+	obj.MustSet("c", 7.9)
+	obj.MustSet("g", int8(8))
+	obj.MustSet("h", -2.5)
+	obj.MustSet("i", -3)
+
+	val, err := obj.Uint("a")
+	a.Nil(err, "err is nil")
+	a.Equal(uint(5), val, "val is correct")
+	a.Equal(uint(6), obj.MustUint("b"), "string is coerced")
+	a.Equal(uint(7), obj.MustUint("c"), "float is truncated")
+	a.Equal(uint(8), obj.MustUint("g"), "go int is coerced")
+
+	_, err = obj.Uint("d")
+	a.NotNil(err, "err is not nil")
+	_, err = obj.Uint("h")
+	a.Equal("type assertion to uint failed", err.Error(), "error message is correct")
+	_, err = obj.Uint("i")
+	a.Equal("type assertion to uint failed", err.Error(), "error message is correct")
+	_, err = obj.Uint("z")
+	a.Equal([]interface{}{"z"}, err.(*PathError).MissingPath, "error MissingPath is correct")
+
+	a.Equal(uint(9), obj.UintOrDefault(9, "f"), "default is returned")
+	a.Equal(uint(5), obj.UintOrDefault(9, "a"), "value is returned")
+}
+
+func Test_UintSlice(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`{"a":[0,"1",2],"b":[0,-1],"c":"hi"}`)
+	a.Nil(err, "err is nil")
+
+	val, err := obj.UintSlice("a")
+	a.Nil(err, "err is nil")
+	a.Equal([]uint{0, 1, 2}, val, "val is correct")
+	a.Equal([]uint{0, 1, 2}, obj.MustUintSlice("a"), "val is correct")
+
+	val, err = obj.UintSlice("b")
+	a.NotNil(err, "err is not nil")
+	a.Nil(val, "val is nil")
+	_, err = obj.UintSlice("c")
+	a.NotNil(err, "err is not nil")
+	a.Equal([]uint{3}, obj.UintSliceOrDefault([]uint{3}, "c"), "default is returned")
+}
+
+func Test_Number(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`{"a":12345678901234567890123,"b":"1.50","c":true,"d":"x"}`)
+	a.Nil(err, "err is nil")
+	obj.MustSet("e", 2.5)
+	obj.MustSet("f", int64(-7))
+
+	val, err := obj.Number("a")
+	a.Nil(err, "err is nil")
+	a.Equal(json.Number("12345678901234567890123"), val, "large integer is exact")
+	a.Equal(json.Number("1.50"), obj.MustNumber("b"), "numeric string is kept exactly")
+	a.Equal(json.Number("2.5"), obj.MustNumber("e"), "float is converted")
+	a.Equal(json.Number("-7"), obj.MustNumber("f"), "int is converted")
+
+	_, err = obj.Number("c")
+	a.Equal("type assertion to number failed", err.Error(), "error message is correct")
+	_, err = obj.Number("d")
+	a.Equal("type assertion to number failed", err.Error(), "error message is correct")
+	_, err = obj.Number("z")
+	a.Equal([]interface{}{"z"}, err.(*PathError).MissingPath, "error MissingPath is correct")
+	a.Equal(json.Number("0"), obj.NumberOrDefault("0", "c"), "default is returned")
+	a.Equal(json.Number("2.5"), obj.NumberOrDefault("0", "e"), "val is returned")
+}
+
+func Test_BigInt(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`{"a":1234567890123456789012345678901234567890,"b":"-9999999999999999999999","c":1.5e3,"d":1.5,"e":"x"}`)
+	a.Nil(err, "err is nil")
+	obj.MustSet("f", uint64(18446744073709551615))
+
+	val, err := obj.BigInt("a")
+	a.Nil(err, "err is nil")
+	a.Equal("1234567890123456789012345678901234567890", val.String(), "40 digit integer is exact")
+	a.Equal("-9999999999999999999999", obj.MustBigInt("b").String(), "string is parsed")
+	a.Equal("1500", obj.MustBigInt("c").String(), "integral float is accepted")
+	a.Equal("18446744073709551615", obj.MustBigInt("f").String(), "uint64 is converted")
+
+	_, err = obj.BigInt("d")
+	a.Equal("value 1.5 is not an integer", err.Error(), "error message is correct")
+	_, err = obj.BigInt("e")
+	a.Equal("type assertion to number failed", err.Error(), "error message is correct")
+	a.Equal(big.NewInt(7), obj.BigIntOrDefault(big.NewInt(7), "d"), "default is returned")
+}
+
+func Test_BigFloat(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`{"a":0.1,"b":"0.12345678901234567890123","c":-1e-30,"d":true}`)
+	a.Nil(err, "err is nil")
+
+	val, err := obj.BigFloat("a")
+	a.Nil(err, "err is nil")
+	a.Equal("0.1", val.Text('g', -1), "decimal keeps its digits")
+	a.Equal("0.100000000000000005551115123126", big.NewFloat(obj.MustFloat64("a")).Text('g', 30), "float64 is an approximation")
+
+	a.Equal("0.12345678901234567890123", obj.MustBigFloat("b").Text('f', 23), "all digits are kept")
+	a.NotEqual("0.12345678901234567890123", strconv.FormatFloat(obj.MustFloat64("b"), 'f', 23, 64), "float64 loses digits")
+	a.Equal("-1e-30", obj.MustBigFloat("c").Text('g', -1), "exponent is parsed")
+
+	_, err = obj.BigFloat("d")
+	a.Equal("type assertion to number failed", err.Error(), "error message is correct")
+	def := big.NewFloat(1)
+	a.Equal(def, obj.BigFloatOrDefault(def, "d"), "default is returned")
+}
+
+func Test_NumberSlice(t *testing.T) {
+	a := assert.New(t)
+
+	obj := FromInterface([]interface{}{json.Number("12345678901234567890"), 1.5, float32(0.1), "2e3", -4, uint8(5)})
+
+	val, err := obj.NumberSlice()
+	a.Nil(err, "err is nil")
+	a.Equal([]json.Number{"12345678901234567890", "1.5", "0.1", "2e3", "-4", "5"}, val, "val is correct")
+	a.Equal(val, obj.MustNumberSlice(), "val is correct")
+}
+
+func Test_NumberSlice_NoneNumberValue(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`[1,"1/2"]`)
+	a.Nil(err, "err is nil")
+
+	val, err := obj.NumberSlice()
+	a.Equal("element 1 value 1/2 is not a number", err.Error(), "error message is correct")
+	a.Nil(val, "val is nil")
+	a.Equal([]json.Number{"0"}, obj.NumberSliceOrDefault([]json.Number{"0"}), "val is default")
+}
+
+func Test_UnmarshalSlice(t *testing.T) {
+	a := assert.New(t)
+
+	type user struct {
+		Name string `json:"name"`
+		Age  int    `json:"age"`
+	}
+	obj, err := FromString(`{"users":[{"name":"bob","age":30},{"name":"ann"}]}`)
+	a.Nil(err, "err is nil")
+
+	users, err := UnmarshalSlice[user](obj, "users")
+	a.Nil(err, "err is nil")
+	a.Equal([]user{{"bob", 30}, {"ann", 0}}, users, "users is correct")
+	a.Equal(users, MustUnmarshalSlice[user](obj, "users"), "users is correct")
+
+	obj.MustSet("users", 1, "age", "old")
+	users, err = UnmarshalSlice[user](obj, "users")
+	a.Nil(users, "users is nil")
+	a.Contains(err.Error(), "element 1: ", "error message names the element")
+}
+
+func Test_Unmarshal(t *testing.T) {
+	a := assert.New(t)
+
+	type user struct {
+		Name string `json:"name"`
+		Age  int    `json:"age"`
+	}
+	obj, err := FromString(`{"users":[{"name":"bob","age":30},{"name":"ann","age":"old"}]}`)
+	a.Nil(err, "err is nil")
+
+	var u user
+	err = obj.Unmarshal(&u, "users", 0)
+	a.Nil(err, "err is nil")
+	a.Equal(user{"bob", 30}, u, "user is correct")
+	var us []map[string]interface{}
+	obj.MustUnmarshal(&us, "users")
+	a.Equal("old", us[1]["age"], "users is correct")
+
+	err = obj.Unmarshal(&u, "users", 2)
+	a.IsType(&PathError{}, err, "path error is returned")
+	err = obj.Unmarshal(&u, "users", 1)
+	a.IsType(&json.UnmarshalTypeError{}, err, "unmarshal error is returned")
+	err = obj.Unmarshal(u, "users", 0)
+	a.IsType(&json.InvalidUnmarshalError{}, err, "target must be a pointer")
+	a.Panics(func() { obj.MustUnmarshal(&u, "nope") }, "panics on error")
+}
+
+func Test_SetStruct(t *testing.T) {
+	a := assert.New(t)
+
+	type address struct {
+		City string `json:"city"`
+	}
+	type user struct {
+		Name    string   `json:"name"`
+		Age     int      `json:"age,omitempty"`
+		Tags    []string `json:"tags"`
+		Address address  `json:"address"`
+		secret  string
+	}
+	obj, err := FromString(`{"users":[null]}`)
+	a.Nil(err, "err is nil")
+
+	err = obj.SetStruct([]interface{}{"users", 0}, user{Name: "bob", Tags: []string{"a"}, Address: address{"york"}, secret: "x"})
+	a.Nil(err, "err is nil")
+	a.Equal(`{"users":[{"address":{"city":"york"},"name":"bob","tags":["a"]}]}`, obj.MustToString(), "tags are honored")
+	a.Equal("york", obj.MustString("users", 0, "address", "city"), "nested struct is a map")
+	a.Equal([]string{"a"}, obj.MustStringSlice("users", 0, "tags"), "slice is a []interface{}")
+
+	obj.MustSetStruct([]interface{}{"users", 0, "age"}, 30)
+	a.Equal(json.Number("30"), obj.MustNumber("users", 0, "age"), "numbers are json.Number")
+	var u user
+	obj.MustUnmarshal(&u, "users", 0)
+	a.Equal(user{Name: "bob", Age: 30, Tags: []string{"a"}, Address: address{"york"}}, u, "round trip is correct")
+
+	obj.MustSetStruct(nil, map[string]int{"a": 1})
+	a.Equal(`{"a":1}`, obj.MustToString(), "nil path sets the root")
+
+	err = obj.SetStruct([]interface{}{"a"}, make(chan int))
+	a.NotNil(err, "err is not nil")
+	a.Panics(func() { obj.MustSetStruct([]interface{}{"a"}, func() {}) }, "panics on error")
+}
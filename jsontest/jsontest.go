@@ -0,0 +1,139 @@
+// Package jsontest provides assertion helpers for reading values from a `*json.Json`
+// in tests, failing the test with a message naming the path and the actual type of
+// the value found instead of panicking like the `Must` methods.
+package jsontest
+
+import (
+	"github.com/0xor1/json"
+	"testing"
+	"time"
+)
+
+// MustGet is a call to Get that fails `t` on none nil error
+func MustGet(t testing.TB, js *json.Json, path ...interface{}) *json.Json {
+	t.Helper()
+	v, err := js.Get(path...)
+	if err != nil {
+		fail(t, js, path, "*json.Json", err)
+	}
+	return v
+}
+
+// MustGetMap is a call to Map that fails `t` on none nil error
+func MustGetMap(t testing.TB, js *json.Json, path ...interface{}) map[string]interface{} {
+	t.Helper()
+	v, err := js.Map(path...)
+	if err != nil {
+		fail(t, js, path, "map[string]interface{}", err)
+	}
+	return v
+}
+
+// MustGetSlice is a call to Slice that fails `t` on none nil error
+func MustGetSlice(t testing.TB, js *json.Json, path ...interface{}) []interface{} {
+	t.Helper()
+	v, err := js.Slice(path...)
+	if err != nil {
+		fail(t, js, path, "[]interface{}", err)
+	}
+	return v
+}
+
+// MustGetBool is a call to Bool that fails `t` on none nil error
+func MustGetBool(t testing.TB, js *json.Json, path ...interface{}) bool {
+	t.Helper()
+	v, err := js.Bool(path...)
+	if err != nil {
+		fail(t, js, path, "bool", err)
+	}
+	return v
+}
+
+// MustGetString is a call to String that fails `t` on none nil error
+func MustGetString(t testing.TB, js *json.Json, path ...interface{}) string {
+	t.Helper()
+	v, err := js.String(path...)
+	if err != nil {
+		fail(t, js, path, "string", err)
+	}
+	return v
+}
+
+// MustGetStringSlice is a call to StringSlice that fails `t` on none nil error
+func MustGetStringSlice(t testing.TB, js *json.Json, path ...interface{}) []string {
+	t.Helper()
+	v, err := js.StringSlice(path...)
+	if err != nil {
+		fail(t, js, path, "[]string", err)
+	}
+	return v
+}
+
+// MustGetInt is a call to Int that fails `t` on none nil error
+func MustGetInt(t testing.TB, js *json.Json, path ...interface{}) int {
+	t.Helper()
+	v, err := js.Int(path...)
+	if err != nil {
+		fail(t, js, path, "int", err)
+	}
+	return v
+}
+
+// MustGetInt64 is a call to Int64 that fails `t` on none nil error
+func MustGetInt64(t testing.TB, js *json.Json, path ...interface{}) int64 {
+	t.Helper()
+	v, err := js.Int64(path...)
+	if err != nil {
+		fail(t, js, path, "int64", err)
+	}
+	return v
+}
+
+// MustGetUint64 is a call to Uint64 that fails `t` on none nil error
+func MustGetUint64(t testing.TB, js *json.Json, path ...interface{}) uint64 {
+	t.Helper()
+	v, err := js.Uint64(path...)
+	if err != nil {
+		fail(t, js, path, "uint64", err)
+	}
+	return v
+}
+
+// MustGetFloat64 is a call to Float64 that fails `t` on none nil error
+func MustGetFloat64(t testing.TB, js *json.Json, path ...interface{}) float64 {
+	t.Helper()
+	v, err := js.Float64(path...)
+	if err != nil {
+		fail(t, js, path, "float64", err)
+	}
+	return v
+}
+
+// MustGetTime is a call to Time that fails `t` on none nil error
+func MustGetTime(t testing.TB, js *json.Json, path ...interface{}) time.Time {
+	t.Helper()
+	v, err := js.Time(path...)
+	if err != nil {
+		fail(t, js, path, "time.Time", err)
+	}
+	return v
+}
+
+// MustGetDuration is a call to Duration that fails `t` on none nil error
+func MustGetDuration(t testing.TB, js *json.Json, path ...interface{}) time.Duration {
+	t.Helper()
+	v, err := js.Duration(path...)
+	if err != nil {
+		fail(t, js, path, "time.Duration", err)
+	}
+	return v
+}
+
+func fail(t testing.TB, js *json.Json, path []interface{}, want string, err error) {
+	t.Helper()
+	if v, getErr := js.Interface(path...); getErr != nil {
+		t.Fatalf("jsontest: %s at %v: %s", want, path, getErr)
+	} else {
+		t.Fatalf("jsontest: %s at %v: %s (actual type %T)", want, path, err, v)
+	}
+}
@@ -0,0 +1,52 @@
+package jsontest
+
+import (
+	"fmt"
+	"github.com/0xor1/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+type fakeTB struct {
+	testing.TB
+	msg string
+}
+
+func (f *fakeTB) Helper() {}
+
+func (f *fakeTB) Fatalf(format string, args ...interface{}) {
+	f.msg = fmt.Sprintf(format, args...)
+}
+
+func Test_MustGet(t *testing.T) {
+	a := assert.New(t)
+
+	js := json.MustFromString(`{"a":{"b":"hi","c":[1,2],"d":true,"e":1.5,"f":"1s","g":"2019-02-27T00:00:00Z","h":["x"]}}`)
+
+	a.Equal("hi", MustGetString(t, MustGet(t, js, "a"), "b"), "val is correct")
+	a.Equal(1, len(MustGetMap(t, js)), "val is correct")
+	a.Equal(2, len(MustGetSlice(t, js, "a", "c")), "val is correct")
+	a.True(MustGetBool(t, js, "a", "d"), "val is correct")
+	a.Equal([]string{"x"}, MustGetStringSlice(t, js, "a", "h"), "val is correct")
+	a.Equal(2, MustGetInt(t, js, "a", "c", 1), "val is correct")
+	a.Equal(int64(1), MustGetInt64(t, js, "a", "c", 0), "val is correct")
+	a.Equal(uint64(1), MustGetUint64(t, js, "a", "c", 0), "val is correct")
+	a.Equal(1.5, MustGetFloat64(t, js, "a", "e"), "val is correct")
+	a.Equal(time.Second, MustGetDuration(t, js, "a", "f"), "val is correct")
+	a.Equal(time.Date(2019, 2, 27, 0, 0, 0, 0, time.UTC), MustGetTime(t, js, "a", "g"), "val is correct")
+}
+
+func Test_MustGet_Failures(t *testing.T) {
+	a := assert.New(t)
+
+	js := json.MustFromString(`{"a":{"b":true}}`)
+
+	ft := &fakeTB{TB: t}
+	a.Equal("", MustGetString(ft, js, "a", "b"), "val is zero")
+	a.Equal("jsontest: string at [a b]: type assertion to string failed (actual type bool)", ft.msg, "msg is correct")
+
+	ft = &fakeTB{TB: t}
+	a.Equal(0, MustGetInt(ft, js, "a", "c"), "val is zero")
+	a.Equal("jsontest: int at [a c]: found: [a] missing: [c]", ft.msg, "msg is correct")
+}
@@ -0,0 +1,83 @@
+package json
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/0xor1/panic"
+)
+
+// Kind is the JSON type of a value, the constants are prefixed to avoid clashing
+// with the Object and Array builders
+type Kind int
+
+const (
+	KindNull Kind = iota
+	KindBool
+	KindNumber
+	KindString
+	KindArray
+	KindObject
+)
+
+var kindNames = []string{"null", "bool", "number", "string", "array", "object"}
+
+// String returns the lower case JSON name of the kind, e.g. "object"
+func (k Kind) String() string {
+	if k >= 0 && int(k) < len(kindNames) {
+		return kindNames[k]
+	}
+	return fmt.Sprintf("Kind(%d)", int(k))
+}
+
+// Type returns the `Kind` of the value at `path`
+func (j *Json) Type(path ...interface{}) (Kind, error) {
+	tmp, err := j.Get(path...)
+	if err != nil {
+		return KindNull, err
+	}
+	return kindOf(tmp.data)
+}
+
+// MustType is a call to Type with a panic on none nil error
+func (j *Json) MustType(path ...interface{}) Kind {
+	k, err := j.Type(path...)
+	panic.IfNotNil(err)
+	return k
+}
+
+// kindOf returns the `Kind` of `v`, values other than those produced by decoding
+// are classified by the first byte of their JSON encoding
+func kindOf(v interface{}) (Kind, error) {
+	switch v.(type) {
+	case nil:
+		return KindNull, nil
+	case bool:
+		return KindBool, nil
+	case string:
+		return KindString, nil
+	case []interface{}:
+		return KindArray, nil
+	case map[string]interface{}:
+		return KindObject, nil
+	}
+	if _, ok := toNumber(v); ok {
+		return KindNumber, nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return KindNull, err
+	}
+	switch b[0] {
+	case 'n':
+		return KindNull, nil
+	case 't', 'f':
+		return KindBool, nil
+	case '"':
+		return KindString, nil
+	case '[':
+		return KindArray, nil
+	case '{':
+		return KindObject, nil
+	}
+	return KindNumber, nil
+}
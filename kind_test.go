@@ -0,0 +1,36 @@
+package json
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func Test_Type(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`{"a":null,"b":true,"c":1.5,"d":"hi","e":[],"f":{}}`)
+	a.Nil(err, "err is nil")
+
+	a.Equal(KindObject, obj.MustType(), "root is an object")
+	a.Equal(KindNull, obj.MustType("a"), "null kind is correct")
+	a.Equal(KindBool, obj.MustType("b"), "bool kind is correct")
+	a.Equal(KindNumber, obj.MustType("c"), "number kind is correct")
+	a.Equal(KindString, obj.MustType("d"), "string kind is correct")
+	a.Equal(KindArray, obj.MustType("e"), "array kind is correct")
+	a.Equal(KindObject, obj.MustType("f"), "object kind is correct")
+
+	obj.MustSet("g", 3)
+	obj.MustSet("h", time.Time{})
+	obj.MustSet("i", []string{"x"})
+	a.Equal(KindNumber, obj.MustType("g"), "go int is a number")
+	a.Equal(KindString, obj.MustType("h"), "time is a string")
+	a.Equal(KindArray, obj.MustType("i"), "go slice is an array")
+
+	_, err = obj.Type("z")
+	a.Equal([]interface{}{"z"}, err.(*PathError).MissingPath, "error MissingPath is correct")
+
+	a.Equal("object", KindObject.String(), "kind string is correct")
+	a.Equal("null", KindNull.String(), "kind string is correct")
+	a.Equal("Kind(9)", Kind(9).String(), "unknown kind string is correct")
+}
@@ -0,0 +1,172 @@
+package json
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/0xor1/panic"
+	"io"
+)
+
+// Limits caps the size of a document during decoding, a zero field is unlimited
+type Limits struct {
+	// MaxStringLen is the most bytes in any string, including object keys
+	MaxStringLen int
+	// MaxArrayLen is the most elements in any array
+	MaxArrayLen int
+	// MaxObjectKeys is the most keys in any object
+	MaxObjectKeys int
+	// MaxDepth is the most deeply nested objects and arrays may be, the root being at depth 1
+	MaxDepth int
+}
+
+// LimitError is returned by FromBytesWithLimits when a document exceeds `Limits`,
+// `Limit` naming the exceeded field of `Limits`, `Max` its value and `Path` where in
+// the document it was exceeded
+//		var le *json.LimitError
+//		if errors.As(err, &le) {
+//			fmt.Println(le.Limit, le.Max)
+//		}
+type LimitError struct {
+	Limit string
+	Max   int
+	Path  []interface{}
+}
+
+func (e *LimitError) Error() string {
+	return fmt.Sprintf("limit %s of %d exceeded at %q", e.Limit, e.Max, dottedPath(e.Path))
+}
+
+// FromBytesWithLimits returns a pointer to a new `Json` object after unmarshaling `b`,
+// enforcing `limits` as each token is decoded so an offending document is rejected
+// before it is fully built. The error names the limit exceeded and the dotted path at
+// which it was, e.g. `limit MaxArrayLen of 100 exceeded at "items"`.
+func FromBytesWithLimits(b []byte, limits Limits) (*Json, error) {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	data, err := decodeLimited(dec, []interface{}{}, 0, &limits)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, fmt.Errorf("invalid data after top-level value")
+	}
+	return &Json{data: data}, nil
+}
+
+// MustFromBytesWithLimits is a call to FromBytesWithLimits with a panic on none nil error
+func MustFromBytesWithLimits(b []byte, limits Limits) *Json {
+	js, err := FromBytesWithLimits(b, limits)
+	panic.IfNotNil(err)
+	return js
+}
+
+// FromBytesMaxDepth is a call to FromBytesWithLimits limiting only the depth, so
+// maliciously deeply nested input is rejected before it is fully decoded rather
+// than overflowing the stack of recursive code processing it later. As for
+// `Limits.MaxDepth` the root object or array is at depth 1 and zero is unlimited.
+func FromBytesMaxDepth(b []byte, maxDepth int) (*Json, error) {
+	return FromBytesWithLimits(b, Limits{MaxDepth: maxDepth})
+}
+
+// MustFromBytesMaxDepth is a call to FromBytesMaxDepth with a panic on none nil error
+func MustFromBytesMaxDepth(b []byte, maxDepth int) *Json {
+	js, err := FromBytesMaxDepth(b, maxDepth)
+	panic.IfNotNil(err)
+	return js
+}
+
+// Depth returns the nesting depth of the document, as counted by `Limits.MaxDepth`,
+// a scalar has depth 0 and an object or array one more than its deepest child. It
+// does not recurse so it is safe to call on arbitrarily deep documents.
+//		{"a":[1,{}]} => 3
+func (j *Json) Depth() int {
+	type node struct {
+		v     interface{}
+		depth int
+	}
+	max := 0
+	stack := []node{{j.data, 0}}
+	for len(stack) > 0 {
+		n := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		switch c := n.v.(type) {
+		case map[string]interface{}:
+			for _, e := range c {
+				stack = append(stack, node{e, n.depth + 1})
+			}
+		case []interface{}:
+			for _, e := range c {
+				stack = append(stack, node{e, n.depth + 1})
+			}
+		default:
+			continue
+		}
+		if n.depth+1 > max {
+			max = n.depth + 1
+		}
+	}
+	return max
+}
+
+// decodeLimited decodes the next value from `dec`, which is at `path` and nested in
+// `depth` objects and arrays, returning a `LimitError` as soon as `limits` is exceeded
+func decodeLimited(dec *json.Decoder, path []interface{}, depth int, limits *Limits) (interface{}, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	exceeded := func(limit string, max int) error {
+		return &LimitError{limit, max, append([]interface{}{}, path...)}
+	}
+	switch tok {
+	case json.Delim('{'), json.Delim('['):
+		if limits.MaxDepth > 0 && depth+1 > limits.MaxDepth {
+			return nil, exceeded("MaxDepth", limits.MaxDepth)
+		}
+	}
+	switch tok {
+	case json.Delim('{'):
+		m := map[string]interface{}{}
+		for dec.More() {
+			keyTok, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			key := keyTok.(string)
+			if limits.MaxStringLen > 0 && len(key) > limits.MaxStringLen {
+				return nil, exceeded("MaxStringLen", limits.MaxStringLen)
+			}
+			if _, exists := m[key]; !exists && limits.MaxObjectKeys > 0 && len(m) == limits.MaxObjectKeys {
+				return nil, exceeded("MaxObjectKeys", limits.MaxObjectKeys)
+			}
+			if m[key], err = decodeLimited(dec, append(path, key), depth+1, limits); err != nil {
+				return nil, err
+			}
+		}
+		if _, err := dec.Token(); err != nil {
+			return nil, err
+		}
+		return m, nil
+	case json.Delim('['):
+		a := []interface{}{}
+		for dec.More() {
+			if limits.MaxArrayLen > 0 && len(a) == limits.MaxArrayLen {
+				return nil, exceeded("MaxArrayLen", limits.MaxArrayLen)
+			}
+			val, err := decodeLimited(dec, append(path, len(a)), depth+1, limits)
+			if err != nil {
+				return nil, err
+			}
+			a = append(a, val)
+		}
+		if _, err := dec.Token(); err != nil {
+			return nil, err
+		}
+		return a, nil
+	}
+	if s, ok := tok.(string); ok && limits.MaxStringLen > 0 && len(s) > limits.MaxStringLen {
+		return nil, exceeded("MaxStringLen", limits.MaxStringLen)
+	}
+	return tok, nil
+}
@@ -0,0 +1,82 @@
+package json
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func Test_FromBytesWithLimits(t *testing.T) {
+	a := assert.New(t)
+
+	limits := Limits{MaxStringLen: 5, MaxArrayLen: 3, MaxObjectKeys: 2, MaxDepth: 3}
+	obj, err := FromBytesWithLimits([]byte(`{"a":[1,"abcde",{"b":null}],"c":true}`), limits)
+	a.Nil(err, "err is nil")
+	a.Equal(`{"a":[1,"abcde",{"b":null}],"c":true}`, obj.MustToString(), "str is correct value")
+	a.Equal(json.Number("1"), obj.MustInterface("a", 0), "numbers are json.Number")
+
+	for _, tc := range []struct {
+		doc string
+		err string
+	}{
+		{`{"a":["abcdef"]}`, `limit MaxStringLen of 5 exceeded at "a.0"`},
+		{`{"abcdef":1}`, `limit MaxStringLen of 5 exceeded at ""`},
+		{`{"a":{"b":[1,2,3,4]}}`, `limit MaxArrayLen of 3 exceeded at "a.b"`},
+		{`[{"a":1,"b":2,"c":3}]`, `limit MaxObjectKeys of 2 exceeded at "0"`},
+		{`{"a":[[[1]]]}`, `limit MaxDepth of 3 exceeded at "a.0.0"`},
+	} {
+		obj, err = FromBytesWithLimits([]byte(tc.doc), limits)
+		a.Nil(obj, "obj is nil")
+		a.Equal(tc.err, err.Error(), "error message is correct")
+	}
+
+	_, err = FromBytesWithLimits([]byte(`{"a":{"b":[1,2,3,4]}}`), limits)
+	var le *LimitError
+	a.True(errors.As(err, &le), "err is a LimitError")
+	a.Equal(&LimitError{Limit: "MaxArrayLen", Max: 3, Path: []interface{}{"a", "b"}}, le, "err is correct")
+	_, err = FromBytesWithLimits([]byte(`{"a":`), limits)
+	a.False(errors.As(err, &le), "syntax error is not a LimitError")
+
+	obj = MustFromBytesWithLimits([]byte(`{"a":"b","a":"c"}`), Limits{MaxObjectKeys: 1})
+	a.Equal("c", obj.MustString("a"), "duplicate keys count once")
+	obj = MustFromBytesWithLimits([]byte(`[[[["deep and long"]]]]`), Limits{})
+	a.Equal("deep and long", obj.MustString(0, 0, 0, 0), "zero limits are unlimited")
+
+	_, err = FromBytesWithLimits([]byte(`{"a":1} {}`), Limits{})
+	a.Equal("invalid data after top-level value", err.Error(), "error message is correct")
+	_, err = FromBytesWithLimits([]byte(`{"a":`), Limits{})
+	a.NotNil(err, "err is not nil")
+}
+
+func Test_FromBytesMaxDepth(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromBytesMaxDepth([]byte(`{"a":[1,{"b":[]}]}`), 4)
+	a.Nil(err, "err is nil")
+	a.Equal(4, obj.Depth(), "depth is at the limit")
+
+	_, err = FromBytesMaxDepth([]byte(`{"a":[1,{"b":[]}]}`), 3)
+	a.Equal(`limit MaxDepth of 3 exceeded at "a.1.b"`, err.Error(), "error message is correct")
+
+	deep := []byte(strings.Repeat("[", 10000) + strings.Repeat("]", 10000))
+	_, err = FromBytesMaxDepth(deep, 64)
+	a.Equal(`limit MaxDepth of 64 exceeded at "`+strings.TrimSuffix(strings.Repeat("0.", 64), ".")+`"`, err.Error(), "deep nesting is rejected")
+	a.Panics(func() { MustFromBytesMaxDepth(deep, 100) }, "panics on error")
+	a.Equal(1, MustFromBytesMaxDepth([]byte(`[]`), 0).Depth(), "zero is unlimited")
+}
+
+func Test_Depth(t *testing.T) {
+	a := assert.New(t)
+
+	a.Equal(0, MustFromString(`1`).Depth(), "scalar has depth 0")
+	a.Equal(1, MustFromString(`{}`).Depth(), "empty object has depth 1")
+	a.Equal(3, MustFromString(`{"a":[1,{}],"b":{"c":1}}`).Depth(), "deepest branch is counted")
+
+	var data interface{} = "x"
+	for i := 0; i < 100000; i++ {
+		data = []interface{}{data}
+	}
+	a.Equal(100000, FromInterface(data).Depth(), "very deep documents are measured")
+}
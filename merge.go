@@ -0,0 +1,154 @@
+package json
+
+import (
+	"fmt"
+	"github.com/0xor1/panic"
+)
+
+// mergeData deep merges `src` into `dst` returning the result, where both are objects
+// `dst` is updated in place, merging recursively, otherwise a copy of `src` replaces `dst`
+func mergeData(dst, src interface{}) interface{} {
+	dm, dOk := dst.(map[string]interface{})
+	sm, sOk := src.(map[string]interface{})
+	if !dOk || !sOk {
+		return cloneData(src)
+	}
+	for k, v := range sm {
+		dm[k] = mergeData(dm[k], v)
+	}
+	return dm
+}
+
+// Merge deep merges `other` into `j`, where both have an object at the same key the
+// objects are merged recursively, otherwise the value from `other` replaces that in
+// `j`, so arrays are replaced wholesale. Values are copied from `other`, which is not
+// modified.
+func (j *Json) Merge(other *Json) error {
+	return j.MergeAt(nil, other)
+}
+
+// MustMerge is a call to Merge with a panic on none nil error
+func (j *Json) MustMerge(other *Json) *Json {
+	panic.IfNotNil(j.Merge(other))
+	return j
+}
+
+// MergeAt is a call to Merge merging `other` into the value at `path`
+func (j *Json) MergeAt(path []interface{}, other *Json) error {
+	dst, err := j.Get(path...)
+	if err != nil {
+		return err
+	}
+	src, err := other.Get()
+	if err != nil {
+		return fmt.Errorf("other: %s", err)
+	}
+	return j.setAt(path, mergeData(dst.data, src.data))
+}
+
+// MustMergeAt is a call to MergeAt with a panic on none nil error
+func (j *Json) MustMergeAt(path []interface{}, other *Json) *Json {
+	panic.IfNotNil(j.MergeAt(path, other))
+	return j
+}
+
+// ApplyMergePatch applies the RFC 7386 JSON Merge Patch `patch` to `j`, where the patch
+// is an object its members are merged recursively into the value at the same key, with
+// null members deleting keys, otherwise the patch replaces the document
+func (j *Json) ApplyMergePatch(patch *Json) error {
+	p, err := patch.Get()
+	if err != nil {
+		return fmt.Errorf("patch: %s", err)
+	}
+	j.data = mergePatchData(j.data, p.data)
+	return nil
+}
+
+// MustApplyMergePatch is a call to ApplyMergePatch with a panic on none nil error
+func (j *Json) MustApplyMergePatch(patch *Json) *Json {
+	panic.IfNotNil(j.ApplyMergePatch(patch))
+	return j
+}
+
+// Diff returns the RFC 7386 JSON Merge Patch that turns `from` into `to` when applied
+// with ApplyMergePatch. Removed keys are nulls, added and changed values, including
+// whole arrays, are their new values and unchanged keys are omitted. As merge patches
+// can not set a value to null, a key of `to` that holds null becomes a removal.
+func Diff(from, to *Json) (*Json, error) {
+	f, err := from.Get()
+	if err != nil {
+		return nil, fmt.Errorf("from: %s", err)
+	}
+	t, err := to.Get()
+	if err != nil {
+		return nil, fmt.Errorf("to: %s", err)
+	}
+	return &Json{data: mergeDiff(f.data, t.data)}, nil
+}
+
+// MustDiff is a call to Diff with a panic on none nil error
+func MustDiff(from, to *Json) *Json {
+	js, err := Diff(from, to)
+	panic.IfNotNil(err)
+	return js
+}
+
+// mergePatchData applies the merge patch `patch` to `target` as described by RFC 7386,
+// updating objects of `target` in place
+func mergePatchData(target, patch interface{}) interface{} {
+	pm, ok := patch.(map[string]interface{})
+	if !ok {
+		return cloneData(patch)
+	}
+	tm, ok := target.(map[string]interface{})
+	if !ok {
+		tm = map[string]interface{}{}
+	}
+	for k, v := range pm {
+		if v == nil {
+			delete(tm, k)
+		} else {
+			tm[k] = mergePatchData(tm[k], v)
+		}
+	}
+	return tm
+}
+
+// mergeDiff returns the merge patch turning `from` into `to`
+func mergeDiff(from, to interface{}) interface{} {
+	fm, fOk := from.(map[string]interface{})
+	tm, tOk := to.(map[string]interface{})
+	if !fOk || !tOk {
+		return cloneData(to)
+	}
+	patch := map[string]interface{}{}
+	for k := range fm {
+		if v, exists := tm[k]; !exists || v == nil {
+			if fm[k] != nil || !exists {
+				patch[k] = nil
+			}
+		}
+	}
+	for k, tv := range tm {
+		fv, exists := fm[k]
+		if tv == nil {
+			continue
+		}
+		if !exists {
+			patch[k] = cloneData(tv)
+			continue
+		}
+		_, fObj := fv.(map[string]interface{})
+		_, tObj := tv.(map[string]interface{})
+		if fObj && tObj {
+			if sub := mergeDiff(fv, tv).(map[string]interface{}); len(sub) > 0 {
+				patch[k] = sub
+			}
+		} else if compareData(fv, tv, nil, func([]interface{}, interface{}, interface{}, string) error {
+			return fmt.Errorf("not equal")
+		}) != nil {
+			patch[k] = cloneData(tv)
+		}
+	}
+	return patch
+}
@@ -0,0 +1,81 @@
+package json
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_Merge(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`{"a":{"b":1,"c":[1,2]},"d":"x","e":{"f":1}}`)
+	a.Nil(err, "err is nil")
+	other, err := FromString(`{"a":{"c":[3],"g":true},"d":{"h":1},"e":null}`)
+	a.Nil(err, "err is nil")
+
+	err = obj.Merge(other)
+	a.Nil(err, "err is nil")
+	a.Equal(`{"a":{"b":1,"c":[3],"g":true},"d":{"h":1},"e":null}`, obj.MustToString(), "str is correct value")
+
+	obj.MustSet("d", "h", 2)
+	a.Equal(1, other.MustInt("d", "h"), "other is not modified")
+
+	root := MustFromString(`[1]`)
+	root.MustMerge(MustFromString(`{"a":1}`))
+	a.Equal(`{"a":1}`, root.MustToString(), "none object root is replaced")
+}
+
+func Test_MergeAt(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`{"a":[{"b":{"c":1}}]}`)
+	a.Nil(err, "err is nil")
+
+	err = obj.MergeAt([]interface{}{"a", 0, "b"}, MustFromString(`{"d":2}`))
+	a.Nil(err, "err is nil")
+	obj.MustMergeAt([]interface{}{"a", 0}, MustFromString(`{"e":3}`))
+	a.Equal(`{"a":[{"b":{"c":1,"d":2},"e":3}]}`, obj.MustToString(), "str is correct value")
+
+	err = obj.MergeAt([]interface{}{"x"}, MustFromString(`{}`))
+	a.Equal([]interface{}{"x"}, err.(*PathError).MissingPath, "error MissingPath is correct")
+	err = obj.MergeAt(nil, MustFromString(`{"a":1}`).Maybe("z"))
+	a.Equal("other: found: [] missing: []", err.Error(), "error message is correct")
+}
+
+func Test_ApplyMergePatch(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`{"a":"b","c":{"d":"e","f":"g"},"h":[1]}`)
+	a.Nil(err, "err is nil")
+
+	err = obj.ApplyMergePatch(MustFromString(`{"a":"z","c":{"f":null,"x":{"y":1}},"h":[2]}`))
+	a.Nil(err, "err is nil")
+	a.Equal(`{"a":"z","c":{"d":"e","x":{"y":1}},"h":[2]}`, obj.MustToString(), "str is correct value")
+
+	obj.MustApplyMergePatch(MustFromString(`["x"]`))
+	a.Equal(`["x"]`, obj.MustToString(), "none object patch replaces the document")
+	err = obj.ApplyMergePatch(obj.Maybe("z"))
+	a.Equal("patch: found: [] missing: []", err.Error(), "error message is correct")
+}
+
+func Test_Diff(t *testing.T) {
+	a := assert.New(t)
+
+	from, err := FromString(`{"a":1,"b":{"c":"x","d":[1,2],"e":{"f":1}},"g":true,"n":null,"m":1}`)
+	a.Nil(err, "err is nil")
+	to, err := FromString(`{"a":1.0,"b":{"c":"y","d":[1,2],"e":{"f":1},"h":{"i":[]}},"j":[3],"n":null,"m":null}`)
+	a.Nil(err, "err is nil")
+
+	patch, err := Diff(from, to)
+	a.Nil(err, "err is nil")
+	a.Equal(`{"b":{"c":"y","h":{"i":[]}},"g":null,"j":[3],"m":null}`, patch.MustToString(), "patch is correct")
+
+	from.MustApplyMergePatch(patch)
+	a.Equal(`{"a":1,"b":{"c":"y","d":[1,2],"e":{"f":1},"h":{"i":[]}},"j":[3],"n":null}`, from.MustToString(), "round trip yields to, less null valued keys")
+
+	a.Equal(`{}`, MustDiff(to, to).MustToString(), "no differences is an empty patch")
+	a.Equal(`[1]`, MustDiff(MustFromString(`{}`), MustFromString(`[1]`)).MustToString(), "none object is a replacement")
+
+	_, err = Diff(from, from.Maybe("z"))
+	a.Equal("to: found: [] missing: []", err.Error(), "error message is correct")
+}
@@ -0,0 +1,89 @@
+package json
+
+import (
+	"fmt"
+	"github.com/0xor1/panic"
+)
+
+// SchemaVersionKey is the root object key at which the schema version is stored
+const SchemaVersionKey = "_schemaVersion"
+
+// SchemaVersion returns the int at SchemaVersionKey, a document without one is at
+// version 0
+func (j *Json) SchemaVersion() (int, error) {
+	if _, err := j.Map(); err != nil {
+		return 0, err
+	}
+	if !j.Exists(SchemaVersionKey) {
+		return 0, nil
+	}
+	raw, _ := j.Interface(SchemaVersionKey)
+	v, ok := exactInt64(raw)
+	if !ok {
+		return 0, fmt.Errorf("schema version %v is not an int", raw)
+	}
+	return int(v), nil
+}
+
+// MustSchemaVersion is a call to SchemaVersion with a panic on none nil error
+func (j *Json) MustSchemaVersion() int {
+	v, err := j.SchemaVersion()
+	panic.IfNotNil(err)
+	return v
+}
+
+// SetSchemaVersion sets the int at SchemaVersionKey
+func (j *Json) SetSchemaVersion(version int) error {
+	return j.Set(SchemaVersionKey, version)
+}
+
+// MustSetSchemaVersion is a call to SetSchemaVersion with a panic on none nil error
+func (j *Json) MustSetSchemaVersion(version int) *Json {
+	panic.IfNotNil(j.SetSchemaVersion(version))
+	return j
+}
+
+// Migrate brings the document up to the latest version in `migrations`, where the
+// function at version `v` migrates a document from version `v-1` to `v`, calling
+// each in turn from the current SchemaVersion and setting the version after each.
+// The migrations are applied to a clone that replaces the document only once they
+// have all succeeded, so on error `j` is left unchanged.
+//		js.Migrate(map[int]func(*json.Json) error{
+//			1: func(js *json.Json) error { return js.Set("name", "") },
+//			2: func(js *json.Json) error { return js.Del("legacy") },
+//		})
+func (j *Json) Migrate(migrations map[int]func(*Json) error) error {
+	current, err := j.SchemaVersion()
+	if err != nil {
+		return err
+	}
+	latest := 0
+	for v := range migrations {
+		if v > latest {
+			latest = v
+		}
+	}
+	if current > latest {
+		return fmt.Errorf("schema version %d is newer than the latest migration %d", current, latest)
+	}
+	tmp := j.Clone()
+	for v := current + 1; v <= latest; v++ {
+		migration, ok := migrations[v]
+		if !ok {
+			return fmt.Errorf("missing migration to version %d", v)
+		}
+		if err := migration(tmp); err != nil {
+			return fmt.Errorf("migration to version %d: %s", v, err)
+		}
+		if err := tmp.SetSchemaVersion(v); err != nil {
+			return fmt.Errorf("migration to version %d: %s", v, err)
+		}
+	}
+	j.swap(tmp)
+	return nil
+}
+
+// MustMigrate is a call to Migrate with a panic on none nil error
+func (j *Json) MustMigrate(migrations map[int]func(*Json) error) {
+	panic.IfNotNil(j.Migrate(migrations))
+}
@@ -0,0 +1,63 @@
+package json
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_SchemaVersion(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`{"a":1}`)
+	a.Nil(err, "err is nil")
+
+	v, err := obj.SchemaVersion()
+	a.Nil(err, "err is nil")
+	a.Equal(0, v, "unversioned document is version 0")
+
+	err = obj.SetSchemaVersion(3)
+	a.Nil(err, "err is nil")
+	a.Equal(3, obj.MustSchemaVersion(), "version is set")
+	a.Equal(3, MustFromString(`{"_schemaVersion":3}`).MustSchemaVersion(), "decoded version is read")
+
+	_, err = MustFromString(`{"_schemaVersion":1.5}`).SchemaVersion()
+	a.Equal("schema version 1.5 is not an int", err.Error(), "error message is correct")
+	_, err = MustFromString(`[]`).SchemaVersion()
+	a.NotNil(err, "err is not nil")
+	err = MustFromString(`[]`).SetSchemaVersion(1)
+	a.NotNil(err, "err is not nil")
+}
+
+func Test_Migrate(t *testing.T) {
+	a := assert.New(t)
+
+	migrations := map[int]func(*Json) error{
+		1: func(js *Json) error { return js.Set("name", js.MustString("title")) },
+		2: func(js *Json) error { return js.Del("title") },
+		3: func(js *Json) error { return js.SetArray("tags") },
+	}
+
+	obj := MustFromString(`{"title":"x"}`)
+	err := obj.Migrate(migrations)
+	a.Nil(err, "err is nil")
+	a.Equal(`{"_schemaVersion":3,"name":"x","tags":[]}`, obj.MustToString(), "all migrations are applied")
+
+	obj = MustFromString(`{"_schemaVersion":2,"name":"y"}`)
+	obj.MustMigrate(migrations)
+	a.Equal(`{"_schemaVersion":3,"name":"y","tags":[]}`, obj.MustToString(), "only newer migrations are applied")
+	obj.MustMigrate(migrations)
+	a.Equal(3, obj.MustSchemaVersion(), "latest version is unchanged")
+
+	obj = MustFromString(`{"title":"x"}`)
+	migrations[2] = func(js *Json) error { return errors.New("boom") }
+	err = obj.Migrate(migrations)
+	a.Equal("migration to version 2: boom", err.Error(), "error message is correct")
+	a.Equal(`{"title":"x"}`, obj.MustToString(), "document is unchanged on error")
+
+	delete(migrations, 2)
+	err = obj.Migrate(migrations)
+	a.Equal("missing migration to version 2", err.Error(), "error message is correct")
+	err = MustFromString(`{"_schemaVersion":4}`).Migrate(migrations)
+	a.Equal("schema version 4 is newer than the latest migration 3", err.Error(), "error message is correct")
+}
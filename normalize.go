@@ -0,0 +1,250 @@
+package json
+
+import (
+	"fmt"
+	"github.com/0xor1/panic"
+	"strconv"
+	"strings"
+)
+
+// NormalizeRules describes a preprocessing policy applied by Normalize in a single
+// traversal of a document. Paths in Coerce and Defaults are in the dotted form
+// described by GetPath, refer to keys after KeyCase has been applied, and may use a
+// `*` segment to match every key of an object or element of an array
+//		rules := json.NormalizeRules{
+//			TrimStrings: true,
+//			KeyCase:     strings.ToLower,
+//			Coerce:      map[string]json.Kind{"items.*.price": json.KindNumber},
+//			Defaults:    map[string]interface{}{"page.size": 20},
+//		}
+type NormalizeRules struct {
+	// TrimStrings removes leading and trailing white space from every string value
+	TrimStrings bool
+	// KeyCase, if not nil, renames every object key, it is an error for two keys of
+	// the same object to be renamed to the same key
+	KeyCase func(key string) string
+	// Coerce converts the values at the given paths to the given kinds where possible:
+	// numbers and bools to strings, numeric strings to numbers, strings such as "true"
+	// to bools and none arrays to single element arrays, or empty arrays for null
+	Coerce map[string]Kind
+	// Defaults sets the values at the given paths, creating any missing parent
+	// objects, where they are missing or null
+	Defaults map[string]interface{}
+}
+
+// Then returns the combination of `r` followed by `other`, strings are trimmed if
+// either trims them, `other.KeyCase` is applied to the result of `r.KeyCase`, and
+// `other` takes precedence for any path present in both of their Coerce or Defaults
+func (r NormalizeRules) Then(other NormalizeRules) NormalizeRules {
+	combined := NormalizeRules{
+		TrimStrings: r.TrimStrings || other.TrimStrings,
+		KeyCase:     r.KeyCase,
+		Coerce:      map[string]Kind{},
+		Defaults:    map[string]interface{}{},
+	}
+	if other.KeyCase != nil {
+		if r.KeyCase == nil {
+			combined.KeyCase = other.KeyCase
+		} else {
+			first, second := r.KeyCase, other.KeyCase
+			combined.KeyCase = func(key string) string {
+				return second(first(key))
+			}
+		}
+	}
+	for _, rules := range []NormalizeRules{r, other} {
+		for path, kind := range rules.Coerce {
+			combined.Coerce[path] = kind
+		}
+		for path, def := range rules.Defaults {
+			combined.Defaults[path] = def
+		}
+	}
+	return combined
+}
+
+// Normalize applies `rules` to the document in a single traversal, key casing
+// happens first, then defaults are filled, then strings are trimmed and finally
+// values are coerced, so defaults are themselves trimmed and coerced
+func (j *Json) Normalize(rules NormalizeRules) error {
+	root := &normalizeNode{}
+	for path, kind := range rules.Coerce {
+		kind := kind
+		root.node(parseDottedPath(path)).coerce = &kind
+	}
+	for path, def := range rules.Defaults {
+		segs := parseDottedPath(path)
+		if len(segs) == 0 {
+			if j.data == nil {
+				j.data = cloneData(def)
+			}
+			continue
+		}
+		n := root
+		for _, seg := range segs {
+			n.defaults = true
+			n = n.child(fmt.Sprint(seg))
+		}
+		n.def, n.hasDef = def, true
+	}
+	data, err := normalizeData(j.data, nil, []*normalizeNode{root}, &rules)
+	if err != nil {
+		return err
+	}
+	j.data = data
+	if rules.KeyCase != nil {
+		j.order = nil
+	}
+	return nil
+}
+
+// MustNormalize is a call to Normalize with a panic on none nil error
+func (j *Json) MustNormalize(rules NormalizeRules) {
+	panic.IfNotNil(j.Normalize(rules))
+}
+
+// normalizeNode is a node in the tree of rule paths, `defaults` is set on every node
+// with a default somewhere below it
+type normalizeNode struct {
+	children map[string]*normalizeNode
+	coerce   *Kind
+	def      interface{}
+	hasDef   bool
+	defaults bool
+}
+
+func (n *normalizeNode) child(key string) *normalizeNode {
+	if n.children == nil {
+		n.children = map[string]*normalizeNode{}
+	}
+	c, ok := n.children[key]
+	if !ok {
+		c = &normalizeNode{}
+		n.children[key] = c
+	}
+	return c
+}
+
+func (n *normalizeNode) node(path []interface{}) *normalizeNode {
+	for _, seg := range path {
+		n = n.child(fmt.Sprint(seg))
+	}
+	return n
+}
+
+// matchNormalizeNodes returns the children of `nodes` matching `key`, exact matches
+// before wildcards
+func matchNormalizeNodes(nodes []*normalizeNode, key string) []*normalizeNode {
+	var matched []*normalizeNode
+	for _, n := range nodes {
+		if c, ok := n.children[key]; ok {
+			matched = append(matched, c)
+		}
+	}
+	for _, n := range nodes {
+		if c, ok := n.children["*"]; ok {
+			matched = append(matched, c)
+		}
+	}
+	return matched
+}
+
+func normalizeData(data interface{}, path []interface{}, nodes []*normalizeNode, rules *NormalizeRules) (interface{}, error) {
+	var err error
+	switch v := data.(type) {
+	case map[string]interface{}:
+		if rules.KeyCase != nil {
+			cased := make(map[string]interface{}, len(v))
+			for k, e := range v {
+				ck := rules.KeyCase(k)
+				if _, exists := cased[ck]; exists {
+					return nil, fmt.Errorf("normalize %q: more than one key becomes %q", dottedPath(path), ck)
+				}
+				cased[ck] = e
+			}
+			v = cased
+		}
+		for _, n := range nodes {
+			for k, c := range n.children {
+				if k == "*" || !c.defaults && !c.hasDef {
+					continue
+				}
+				if e, exists := v[k]; !exists || e == nil {
+					if c.hasDef {
+						v[k] = cloneData(c.def)
+					} else {
+						v[k] = map[string]interface{}{}
+					}
+				}
+			}
+		}
+		for k, e := range v {
+			if v[k], err = normalizeData(e, append(path, k), matchNormalizeNodes(nodes, k), rules); err != nil {
+				return nil, err
+			}
+		}
+		data = v
+	case []interface{}:
+		for i, e := range v {
+			if v[i], err = normalizeData(e, append(path, i), matchNormalizeNodes(nodes, strconv.Itoa(i)), rules); err != nil {
+				return nil, err
+			}
+		}
+	case string:
+		if rules.TrimStrings {
+			data = strings.TrimSpace(v)
+		}
+	}
+	for _, n := range nodes {
+		if n.coerce != nil {
+			if data, err = coerceData(data, *n.coerce); err != nil {
+				return nil, fmt.Errorf("normalize %q: %s", dottedPath(path), err)
+			}
+			break
+		}
+	}
+	return data, nil
+}
+
+// coerceData converts `v` to `kind` as described by NormalizeRules.Coerce
+func coerceData(v interface{}, kind Kind) (interface{}, error) {
+	switch kind {
+	case KindString:
+		switch s := v.(type) {
+		case string:
+			return s, nil
+		case bool:
+			return strconv.FormatBool(s), nil
+		}
+		if n, ok := toNumber(v); ok {
+			return n.String(), nil
+		}
+	case KindNumber:
+		if n, ok := toNumber(v); ok {
+			return n, nil
+		}
+	case KindBool:
+		switch b := v.(type) {
+		case bool:
+			return b, nil
+		case string:
+			if parsed, err := strconv.ParseBool(b); err == nil {
+				return parsed, nil
+			}
+		}
+	case KindArray:
+		switch a := v.(type) {
+		case []interface{}:
+			return a, nil
+		case nil:
+			return []interface{}{}, nil
+		}
+		return []interface{}{v}, nil
+	default:
+		if k, err := kindOf(v); err == nil && k == kind {
+			return v, nil
+		}
+	}
+	from, _ := kindOf(v)
+	return nil, fmt.Errorf("can not coerce %s %v to %s", from, v, kind)
+}
@@ -0,0 +1,68 @@
+package json
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func Test_Normalize(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`{"Name":"  bob ","Items":[{"Price":"1.50","Qty":2},{"Price":3}],"Active":"true","Tags":"x","Page":null}`)
+	a.Nil(err, "err is nil")
+
+	err = obj.Normalize(NormalizeRules{
+		TrimStrings: true,
+		KeyCase:     strings.ToLower,
+		Coerce: map[string]Kind{
+			"items.*.price": KindNumber,
+			"items.*.qty":   KindString,
+			"active":        KindBool,
+			"tags":          KindArray,
+			"page.size":     KindNumber,
+		},
+		Defaults: map[string]interface{}{
+			"page.size":    " 20 ",
+			"page.n":       1,
+			"meta.source":  "api",
+			"items.0.note": "first",
+		},
+	})
+	a.Nil(err, "err is nil")
+	a.Equal(`{"active":true,"items":[{"note":"first","price":1.50,"qty":"2"},{"price":3}],"meta":{"source":"api"},"name":"bob","page":{"n":1,"size":20},"tags":["x"]}`, obj.MustToString(), "str is correct value")
+
+	root := &Json{}
+	root.MustNormalize(NormalizeRules{Defaults: map[string]interface{}{"": map[string]interface{}{"a": 1}}})
+	a.Equal(`{"a":1}`, root.MustToString(), "root default is set")
+}
+
+func Test_Normalize_Error(t *testing.T) {
+	a := assert.New(t)
+
+	obj := MustFromString(`{"a":[{"b":"x"}],"B":1,"b":2}`)
+	err := obj.Normalize(NormalizeRules{Coerce: map[string]Kind{"a.*.b": KindNumber}})
+	a.Equal(`normalize "a.0.b": can not coerce string x to number`, err.Error(), "error message is correct")
+
+	err = obj.Normalize(NormalizeRules{KeyCase: strings.ToLower})
+	a.Equal(`normalize "": more than one key becomes "b"`, err.Error(), "error message is correct")
+}
+
+func Test_NormalizeRules_Then(t *testing.T) {
+	a := assert.New(t)
+
+	rules := NormalizeRules{
+		KeyCase:  strings.ToLower,
+		Coerce:   map[string]Kind{"a": KindString, "b": KindString},
+		Defaults: map[string]interface{}{"c": 1},
+	}.Then(NormalizeRules{
+		TrimStrings: true,
+		KeyCase:     func(key string) string { return key + "_" },
+		Coerce:      map[string]Kind{"b_": KindNumber},
+		Defaults:    map[string]interface{}{"c": 2},
+	})
+	a.True(rules.TrimStrings, "trim is combined")
+	a.Equal("x_", rules.KeyCase("X"), "key casings are composed")
+	a.Equal(map[string]Kind{"a": KindString, "b": KindString, "b_": KindNumber}, rules.Coerce, "coercions are merged")
+	a.Equal(map[string]interface{}{"c": 2}, rules.Defaults, "later defaults take precedence")
+}
@@ -0,0 +1,176 @@
+package json
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/0xor1/panic"
+	"reflect"
+	"sort"
+)
+
+// keyOrder records the order in which object keys were decoded, by the identity of
+// each decoded object, so an object keeps its order wherever arrays or other changes
+// move it within the document
+type keyOrder struct {
+	objects map[uintptr]orderedObject
+}
+
+// orderedObject holds the decoded keys of `m`, holding `m` itself so that its identity
+// can not be reused by another object while the order is kept
+type orderedObject struct {
+	m    map[string]interface{}
+	keys []string
+}
+
+func (o *keyOrder) record(m map[string]interface{}, keys []string) {
+	o.objects[reflect.ValueOf(m).Pointer()] = orderedObject{m, keys}
+}
+
+func (o *keyOrder) keysOf(m map[string]interface{}) []string {
+	return o.objects[reflect.ValueOf(m).Pointer()].keys
+}
+
+// cloned returns the order of `dst`, a deep copy of `src` as made by cloneData
+func (o *keyOrder) cloned(src, dst interface{}) *keyOrder {
+	c := &keyOrder{objects: map[uintptr]orderedObject{}}
+	var walk func(src, dst interface{})
+	walk = func(src, dst interface{}) {
+		switch v := src.(type) {
+		case map[string]interface{}:
+			d := dst.(map[string]interface{})
+			if keys := o.keysOf(v); keys != nil {
+				c.record(d, keys)
+			}
+			for k, e := range v {
+				walk(e, d[k])
+			}
+		case []interface{}:
+			d := dst.([]interface{})
+			for i, e := range v {
+				walk(e, d[i])
+			}
+		}
+	}
+	walk(src, dst)
+	return c
+}
+
+// FromBytesKeepOrder returns a pointer to a new `Json` object after unmarshaling `b`,
+// additionally recording the order of the keys in every object so that ToBytes and
+// the other marshaling methods emit them in that order. Keys added later are emitted
+// after the recorded ones in sorted order, as are the keys of objects set into the
+// document later, but decoded objects keep their order when moved. Only the returned
+// `Json` keeps the order, values returned from its Get are marshaled with sorted keys.
+func FromBytesKeepOrder(b []byte) (*Json, error) {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	order := &keyOrder{objects: map[uintptr]orderedObject{}}
+	data, err := decodeOrdered(dec, order)
+	if err != nil {
+		return nil, err
+	}
+	return &Json{data: data, order: order}, nil
+}
+
+// MustFromBytesKeepOrder is a call to FromBytesKeepOrder with a panic on none nil error
+func MustFromBytesKeepOrder(b []byte) *Json {
+	js, err := FromBytesKeepOrder(b)
+	panic.IfNotNil(err)
+	return js
+}
+
+func decodeOrdered(dec *json.Decoder, order *keyOrder) (interface{}, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch tok {
+	case json.Delim('{'):
+		m := map[string]interface{}{}
+		keys := []string{}
+		for dec.More() {
+			keyTok, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			key := keyTok.(string)
+			val, err := decodeOrdered(dec, order)
+			if err != nil {
+				return nil, err
+			}
+			if _, exists := m[key]; !exists {
+				keys = append(keys, key)
+			}
+			m[key] = val
+		}
+		if _, err := dec.Token(); err != nil {
+			return nil, err
+		}
+		order.record(m, keys)
+		return m, nil
+	case json.Delim('['):
+		a := []interface{}{}
+		for dec.More() {
+			val, err := decodeOrdered(dec, order)
+			if err != nil {
+				return nil, err
+			}
+			a = append(a, val)
+		}
+		if _, err := dec.Token(); err != nil {
+			return nil, err
+		}
+		return a, nil
+	}
+	return tok, nil
+}
+
+func encodeOrdered(buf *bytes.Buffer, data interface{}, order *keyOrder) error {
+	switch v := data.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		seen := make(map[string]bool, len(v))
+		for _, k := range order.keysOf(v) {
+			if _, exists := v[k]; exists && !seen[k] {
+				keys = append(keys, k)
+				seen[k] = true
+			}
+		}
+		extra := make([]string, 0, len(v)-len(keys))
+		for k := range v {
+			if !seen[k] {
+				extra = append(extra, k)
+			}
+		}
+		sort.Strings(extra)
+		buf.WriteByte('{')
+		for i, k := range append(keys, extra...) {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			b, _ := json.Marshal(k)
+			buf.Write(b)
+			buf.WriteByte(':')
+			if err := encodeOrdered(buf, v[k], order); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+		return nil
+	case []interface{}:
+		buf.WriteByte('[')
+		for i, e := range v {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := encodeOrdered(buf, e, order); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+		return nil
+	}
+	b, err := json.Marshal(data)
+	buf.Write(b)
+	return err
+}
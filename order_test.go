@@ -0,0 +1,74 @@
+package json
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_FromBytesKeepOrder(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromBytesKeepOrder([]byte(`{"z":1,"a":[{"y":1,"x":2},3],"m":{"c":1,"b":2}}`))
+	a.Nil(err, "err is nil")
+
+	str, err := obj.ToString()
+	a.Nil(err, "err is nil")
+	a.Equal(`{"z":1,"a":[{"y":1,"x":2},3],"m":{"c":1,"b":2}}`, str, "str keeps key order")
+
+	obj.MustSet("m", "c", 5)
+	obj.MustSet("m", "a", 6)
+	obj.MustSet("b", true)
+	obj.MustDel("z")
+	str, err = obj.ToString()
+	a.Nil(err, "err is nil")
+	a.Equal(`{"a":[{"y":1,"x":2},3],"m":{"c":5,"b":2,"a":6},"b":true}`, str, "str keeps key order and appends new keys")
+
+	pretty, err := obj.ToPrettyString()
+	a.Nil(err, "err is nil")
+	a.Equal("{\n  \"a\": [\n    {\n      \"y\": 1,\n      \"x\": 2\n    },\n    3\n  ],\n  \"m\": {\n    \"c\": 5,\n    \"b\": 2,\n    \"a\": 6\n  },\n  \"b\": true\n}", pretty, "pretty str keeps key order")
+
+	str, err = obj.MustGet("m").ToString()
+	a.Nil(err, "err is nil")
+	a.Equal(`{"a":6,"b":2,"c":5}`, str, "sub Json str is sorted")
+
+	arr := MustFromBytesKeepOrder([]byte(`{"a":[{"b":1,"a":2},{"d":3,"c":4},{"f":5,"e":6}]}`))
+	arr.MustDel("a", 0)
+	arr.MustReverse("a")
+	arr.MustSet("a", 2, map[string]interface{}{"h": 7, "g": 8})
+	a.Equal(`{"a":[{"f":5,"e":6},{"d":3,"c":4},{"g":8,"h":7}]}`, arr.MustToString(), "objects keep their order when arrays change")
+	a.Equal(arr.MustToString(), arr.Clone().MustToString(), "clone keeps order")
+}
+
+func Test_FromBytesKeepOrder_Error(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromBytesKeepOrder([]byte(`{"a":[1,}`))
+	a.NotNil(err, "err is not nil")
+	a.Nil(obj, "obj is nil")
+}
+
+func Test_FromBytesKeepOrder_CloneWriteBack(t *testing.T) {
+	a := assert.New(t)
+
+	src := []byte(`{"z":1,"a":2}`)
+
+	obj := MustFromBytesKeepOrder(src)
+	obj.MustCopy([]interface{}{"z"}, []interface{}{"y"})
+	a.Equal(`{"z":1,"a":2,"y":1}`, obj.MustToString(), "copy keeps key order")
+
+	obj = MustFromBytesKeepOrder(src)
+	obj.MustMove([]interface{}{"z"}, []interface{}{"y"})
+	a.Equal(`{"a":2,"y":1}`, obj.MustToString(), "move keeps key order")
+
+	obj = MustFromBytesKeepOrder(src)
+	obj.MustSetMany(map[string]interface{}{"a": 3, "y": 1})
+	a.Equal(`{"z":1,"a":3,"y":1}`, obj.MustToString(), "set many keeps key order")
+
+	obj = MustFromBytesKeepOrder(src)
+	obj.MustApplyPatch(MustFromString(`[{"op":"replace","path":"/a","value":3}]`))
+	a.Equal(`{"z":1,"a":3}`, obj.MustToString(), "apply patch keeps key order")
+
+	obj = MustFromBytesKeepOrder(src)
+	obj.MustMigrate(map[int]func(*Json) error{1: func(js *Json) error { return js.Set("a", 3) }})
+	a.Equal(`{"z":1,"a":3,"_schemaVersion":1}`, obj.MustToString(), "migrate keeps key order")
+}
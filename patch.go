@@ -0,0 +1,217 @@
+package json
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/0xor1/panic"
+	"strings"
+)
+
+// IsValidPatch checks that `j` is a well formed RFC 6902 JSON Patch, an array of
+// operation objects each with a valid `op`, a valid `path` pointer and the `value`
+// or `from` member its op requires, returning an error describing the first
+// malformed operation
+func (j *Json) IsValidPatch() error {
+	ops, err := j.Slice()
+	if err != nil {
+		return fmt.Errorf("patch must be an array: %s", err)
+	}
+	for i, op := range ops {
+		if _, err := patchOp(op); err != nil {
+			return fmt.Errorf("patch operation %d: %s", i, err)
+		}
+	}
+	return nil
+}
+
+// MustIsValidPatch is a call to IsValidPatch with a panic on none nil error
+func (j *Json) MustIsValidPatch() {
+	panic.IfNotNil(j.IsValidPatch())
+}
+
+// patchOperation is a validated RFC 6902 operation
+type patchOperation struct {
+	op    string
+	path  string
+	from  string
+	value interface{}
+}
+
+func patchOp(data interface{}) (*patchOperation, error) {
+	m, ok := data.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("must be an object")
+	}
+	p := &patchOperation{}
+	if p.op, ok = m["op"].(string); !ok {
+		return nil, fmt.Errorf("op must be a string")
+	}
+	if p.path, ok = m["path"].(string); !ok {
+		return nil, fmt.Errorf("path must be a string")
+	}
+	if _, err := pointerTokens(p.path); err != nil {
+		return nil, err
+	}
+	switch p.op {
+	case "add", "replace", "test":
+		if p.value, ok = m["value"]; !ok {
+			return nil, fmt.Errorf("%s requires a value", p.op)
+		}
+	case "move", "copy":
+		if p.from, ok = m["from"].(string); !ok {
+			return nil, fmt.Errorf("%s requires from to be a string", p.op)
+		}
+		if _, err := pointerTokens(p.from); err != nil {
+			return nil, err
+		}
+		if p.op == "move" && p.from != p.path && strings.HasPrefix(p.path, p.from+"/") {
+			return nil, fmt.Errorf("move from %q can not be into one of its children %q", p.from, p.path)
+		}
+	case "remove":
+	default:
+		return nil, fmt.Errorf("unknown op %q", p.op)
+	}
+	return p, nil
+}
+
+// ApplyPatch applies the RFC 6902 JSON Patch `ops` to `j`, supporting the add,
+// remove, replace, move, copy and test operations with their path and from members
+// resolved as by GetPointer. The operations are applied to a clone that replaces the
+// document only once they have all succeeded, so on any error, including a failed
+// test or an operation on a location that does not exist, `j` is left unchanged.
+func (j *Json) ApplyPatch(ops *Json) error {
+	arr, err := ops.Slice()
+	if err != nil {
+		return fmt.Errorf("patch must be an array: %s", err)
+	}
+	tmp := j.Clone()
+	for i, data := range arr {
+		op, err := patchOp(data)
+		if err == nil {
+			err = tmp.applyPatchOp(op)
+		}
+		if err != nil {
+			return fmt.Errorf("patch operation %d: %s", i, err)
+		}
+	}
+	j.swap(tmp)
+	return nil
+}
+
+// MustApplyPatch is a call to ApplyPatch with a panic on none nil error
+func (j *Json) MustApplyPatch(ops *Json) {
+	panic.IfNotNil(j.ApplyPatch(ops))
+}
+
+func (j *Json) applyPatchOp(op *patchOperation) error {
+	switch op.op {
+	case "add":
+		return j.patchAdd(op.path, cloneData(op.value))
+	case "remove":
+		return j.patchRemove(op.path)
+	case "replace":
+		path, err := j.patchTarget(op.path)
+		if err != nil {
+			return err
+		}
+		return j.setAt(path, cloneData(op.value))
+	case "move":
+		if op.from == op.path {
+			_, err := j.patchTarget(op.from)
+			return err
+		}
+		path, err := j.patchTarget(op.from)
+		if err != nil {
+			return err
+		}
+		val, _ := j.Get(path...)
+		if err := j.patchRemove(op.from); err != nil {
+			return err
+		}
+		return j.patchAdd(op.path, val.data)
+	case "copy":
+		path, err := j.patchTarget(op.from)
+		if err != nil {
+			return err
+		}
+		val, _ := j.Get(path...)
+		return j.patchAdd(op.path, cloneData(val.data))
+	case "test":
+		path, err := j.patchTarget(op.path)
+		if err != nil {
+			return err
+		}
+		val, _ := j.Get(path...)
+		if compareData(val.data, op.value, nil, func([]interface{}, interface{}, interface{}, string) error {
+			return fmt.Errorf("not equal")
+		}) != nil {
+			want, _ := json.Marshal(op.value)
+			return fmt.Errorf("test failed: value at %q is not equal to %s", op.path, want)
+		}
+	}
+	return nil
+}
+
+// patchTarget returns the path referenced by `ptr`, which must exist
+func (j *Json) patchTarget(ptr string) ([]interface{}, error) {
+	path, err := j.pointerPath(ptr)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := j.Get(path...); err != nil {
+		return nil, fmt.Errorf("path %q does not exist", ptr)
+	}
+	return path, nil
+}
+
+// patchParent splits `ptr` into the path of its parent, which must exist, and its
+// final unescaped token
+func (j *Json) patchParent(ptr string) ([]interface{}, string, error) {
+	tokens, err := pointerTokens(ptr)
+	if err != nil {
+		return nil, "", err
+	}
+	parentPtr := ptr[:strings.LastIndex(ptr, "/")]
+	parent, err := j.patchTarget(parentPtr)
+	if err != nil {
+		return nil, "", err
+	}
+	return parent, tokens[len(tokens)-1], nil
+}
+
+func (j *Json) patchAdd(ptr string, val interface{}) error {
+	if ptr == "" {
+		j.data = val
+		return nil
+	}
+	parent, token, err := j.patchParent(ptr)
+	if err != nil {
+		return err
+	}
+	container, _ := j.Get(parent...)
+	switch c := container.data.(type) {
+	case map[string]interface{}:
+		c[token] = val
+		return nil
+	case []interface{}:
+		if token == "-" {
+			return j.Append(append(parent, val)...)
+		}
+		if i, ok := pointerIndex(token); ok && i <= len(c) {
+			return j.Insert(append(parent, i, val)...)
+		}
+		return fmt.Errorf("path %q is not a valid array index", ptr)
+	}
+	return fmt.Errorf("path %q parent is not an object or array", ptr)
+}
+
+func (j *Json) patchRemove(ptr string) error {
+	if ptr == "" {
+		return fmt.Errorf("can not remove the whole document")
+	}
+	path, err := j.patchTarget(ptr)
+	if err != nil {
+		return err
+	}
+	return j.Del(path...)
+}
@@ -0,0 +1,103 @@
+package json
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_IsValidPatch(t *testing.T) {
+	a := assert.New(t)
+
+	patch, err := FromString(`[
+		{"op":"add","path":"/a/-","value":null},
+		{"op":"remove","path":"/b"},
+		{"op":"replace","path":"","value":{}},
+		{"op":"move","from":"/a","path":"/c"},
+		{"op":"copy","from":"/a","path":"/a~1b"},
+		{"op":"test","path":"/c","value":1}
+	]`)
+	a.Nil(err, "err is nil")
+	a.Nil(patch.IsValidPatch(), "err is nil")
+	patch.MustIsValidPatch()
+}
+
+func Test_IsValidPatch_Error(t *testing.T) {
+	a := assert.New(t)
+
+	cases := map[string]string{
+		`{}`:  "patch must be an array: type assertion to []interface{} failed",
+		`[1]`: "patch operation 0: must be an object",
+		`[{"op":"add","path":"/a","value":1},{"path":"/a"}]`: "patch operation 1: op must be a string",
+		`[{"op":"add"}]`:                            "patch operation 0: path must be a string",
+		`[{"op":"add","path":"a"}]`:                 `patch operation 0: invalid JSON pointer "a": must be empty or start with /`,
+		`[{"op":"add","path":"/a"}]`:                "patch operation 0: add requires a value",
+		`[{"op":"copy","path":"/a"}]`:               "patch operation 0: copy requires from to be a string",
+		`[{"op":"move","path":"/a/b","from":"/a"}]`: `patch operation 0: move from "/a" can not be into one of its children "/a/b"`,
+		`[{"op":"merge","path":"/a"}]`:              `patch operation 0: unknown op "merge"`,
+	}
+	for str, msg := range cases {
+		patch, err := FromString(str)
+		a.Nil(err, "err is nil")
+		err = patch.IsValidPatch()
+		a.NotNil(err, "err is not nil")
+		if err != nil {
+			a.Equal(msg, err.Error(), "error message is correct")
+		}
+	}
+}
+
+func Test_ApplyPatch(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`{"a":{"b":1},"c":[1,2],"d~e":"x"}`)
+	a.Nil(err, "err is nil")
+
+	err = obj.ApplyPatch(MustFromString(`[
+		{"op":"test","path":"/a/b","value":1},
+		{"op":"add","path":"/a/f","value":{"g":true}},
+		{"op":"add","path":"/c/1","value":9},
+		{"op":"add","path":"/c/-","value":3},
+		{"op":"remove","path":"/c/0"},
+		{"op":"replace","path":"/d~0e","value":"y"},
+		{"op":"copy","from":"/a/f","path":"/h"},
+		{"op":"move","from":"/a/b","path":"/c/0"},
+		{"op":"test","path":"/h","value":{"g":true}}
+	]`))
+	a.Nil(err, "err is nil")
+	a.Equal(`{"a":{"f":{"g":true}},"c":[1,9,2,3],"d~e":"y","h":{"g":true}}`, obj.MustToString(), "str is correct value")
+
+	obj.MustSet("h", "g", false)
+	a.True(obj.MustBool("a", "f", "g"), "copies are not shared")
+
+	root := MustFromString(`{"a":1}`)
+	root.MustApplyPatch(MustFromString(`[{"op":"replace","path":"","value":[1]}]`))
+	a.Equal(`[1]`, root.MustToString(), "root is replaced")
+}
+
+func Test_ApplyPatch_Error(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`{"a":{"b":1},"c":[1,2]}`)
+	a.Nil(err, "err is nil")
+	before := obj.MustToString()
+
+	for _, tc := range []struct {
+		patch string
+		err   string
+	}{
+		{`{}`, "patch must be an array: type assertion to []interface{} failed"},
+		{`[{"op":"add","path":"/x","value":1},{"op":"test","path":"/a/b","value":2}]`, `patch operation 1: test failed: value at "/a/b" is not equal to 2`},
+		{`[{"op":"remove","path":"/a/z"}]`, `patch operation 0: path "/a/z" does not exist`},
+		{`[{"op":"replace","path":"/z","value":1}]`, `patch operation 0: path "/z" does not exist`},
+		{`[{"op":"add","path":"/z/y","value":1}]`, `patch operation 0: path "/z" does not exist`},
+		{`[{"op":"add","path":"/c/3","value":1}]`, `patch operation 0: path "/c/3" is not a valid array index`},
+		{`[{"op":"add","path":"/a/b/c","value":1}]`, `patch operation 0: path "/a/b/c" parent is not an object or array`},
+		{`[{"op":"move","from":"/z","path":"/y"}]`, `patch operation 0: path "/z" does not exist`},
+		{`[{"op":"remove","path":""}]`, `patch operation 0: can not remove the whole document`},
+		{`[{"op":"nope","path":""}]`, `patch operation 0: unknown op "nope"`},
+	} {
+		err = obj.ApplyPatch(MustFromString(tc.patch))
+		a.Equal(tc.err, err.Error(), "error message is correct")
+		a.Equal(before, obj.MustToString(), "document is unchanged")
+	}
+}
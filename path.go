@@ -0,0 +1,142 @@
+package json
+
+import (
+	"fmt"
+	"github.com/0xor1/panic"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// GetPath is a call to Get with `path` in dotted form, segments consisting only of
+// digits are int indices and a `\` escapes a literal `.` or `\` or, when leading a
+// segment of digits, makes it a string key
+//		js.GetPath(`a.1.b\.c`) == js.Get("a", 1, "b.c")
+func (j *Json) GetPath(path string) (*Json, error) {
+	return j.Get(parseDottedPath(path)...)
+}
+
+// MustGetPath is a call to GetPath with a panic on none nil error
+func (j *Json) MustGetPath(path string) *Json {
+	js, err := j.GetPath(path)
+	panic.IfNotNil(err)
+	return js
+}
+
+// SetPath is a call to Set with `path` in the dotted form described by GetPath
+func (j *Json) SetPath(path string, val interface{}) error {
+	return j.setAt(parseDottedPath(path), val)
+}
+
+// MustSetPath is a call to SetPath with a panic on none nil error
+func (j *Json) MustSetPath(path string, val interface{}) *Json {
+	panic.IfNotNil(j.SetPath(path, val))
+	return j
+}
+
+// DelPath is a call to Del with `path` in the dotted form described by GetPath
+func (j *Json) DelPath(path string) error {
+	return j.Del(parseDottedPath(path)...)
+}
+
+// MustDelPath is a call to DelPath with a panic on none nil error
+func (j *Json) MustDelPath(path string) {
+	panic.IfNotNil(j.DelPath(path))
+}
+
+// SetMany is a call to SetPath for each of `updates` in sorted key order, if any
+// fails its error is returned and none of the updates are applied
+//		js.SetMany(map[string]interface{}{"name": "bob", "address.city": "york"})
+func (j *Json) SetMany(updates map[string]interface{}) error {
+	paths := make([]string, 0, len(updates))
+	for path := range updates {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	tmp := j.Clone()
+	for _, path := range paths {
+		if err := tmp.SetPath(path, updates[path]); err != nil {
+			return err
+		}
+	}
+	j.swap(tmp)
+	return nil
+}
+
+// MustSetMany is a call to SetMany with a panic on none nil error
+func (j *Json) MustSetMany(updates map[string]interface{}) *Json {
+	panic.IfNotNil(j.SetMany(updates))
+	return j
+}
+
+var dottedPathEscaper = strings.NewReplacer(`\`, `\\`, `.`, `\.`)
+
+// dottedPath renders `path` in the dotted form `a.1.b`, string segments have any
+// `\` and `.` characters escaped with a `\`, and are prefixed with a `\` if they
+// consist only of digits so they are not mistaken for int indices
+func dottedPath(path []interface{}) string {
+	segs := make([]string, 0, len(path))
+	for _, p := range path {
+		switch seg := p.(type) {
+		case string:
+			if isDigits(seg) {
+				segs = append(segs, `\`+seg)
+			} else {
+				segs = append(segs, dottedPathEscaper.Replace(seg))
+			}
+		case int:
+			segs = append(segs, strconv.Itoa(seg))
+		default:
+			segs = append(segs, fmt.Sprint(seg))
+		}
+	}
+	return strings.Join(segs, ".")
+}
+
+// parseDottedPath is the inverse of dottedPath, splitting `path` on unescaped `.`
+// characters, segments consisting only of digits become int indices
+func parseDottedPath(path string) []interface{} {
+	if path == "" {
+		return []interface{}{}
+	}
+	segs := []interface{}{}
+	seg := strings.Builder{}
+	escaped, quoted := false, false
+	flush := func() {
+		str := seg.String()
+		if i, err := strconv.Atoi(str); err == nil && !quoted && isDigits(str) {
+			segs = append(segs, i)
+		} else {
+			segs = append(segs, str)
+		}
+		seg.Reset()
+		quoted = false
+	}
+	for _, r := range path {
+		switch {
+		case escaped:
+			seg.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			escaped, quoted = true, true
+		case r == '.':
+			flush()
+		default:
+			seg.WriteRune(r)
+		}
+	}
+	if escaped {
+		seg.WriteRune('\\')
+	}
+	flush()
+	return segs
+}
+
+func isDigits(str string) bool {
+	for _, r := range str {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return str != ""
+}
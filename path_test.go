@@ -0,0 +1,71 @@
+package json
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_dottedPath_RoundTrip(t *testing.T) {
+	a := assert.New(t)
+
+	path := []interface{}{"a.b", 1, "0", `c\d`, "", "e"}
+	str := dottedPath(path)
+	a.Equal(`a\.b.1.\0.c\\d..e`, str, "str is correct value")
+	a.Equal(path, parseDottedPath(str), "path round trips")
+	a.Equal([]interface{}{}, parseDottedPath(""), "empty str is empty path")
+}
+
+func Test_GetPath(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`{"a":[{"b":{"c":1}},{"b.c":2,"3":3}]}`)
+	a.Nil(err, "err is nil")
+
+	js, err := obj.GetPath("a.0.b.c")
+	a.Nil(err, "err is nil")
+	a.Equal(1, js.MustInt(), "nested value is correct")
+	a.Equal(2, obj.MustGetPath(`a.1.b\.c`).MustInt(), "escaped dot is part of the key")
+	a.Equal(3, obj.MustGetPath(`a.1.\3`).MustInt(), "escaped digits are a string key")
+
+	_, err = obj.GetPath("a.2")
+	a.Equal([]interface{}{"a"}, err.(*PathError).FoundPath, "error FoundPath is correct")
+	a.Equal([]interface{}{2}, err.(*PathError).MissingPath, "error MissingPath is correct")
+
+	err = obj.SetPath("a.0.b.d.e", true)
+	a.Nil(err, "err is nil")
+	obj.MustSetPath(`x\.y`, "z")
+	a.True(obj.MustBool("a", 0, "b", "d", "e"), "value is set")
+	a.Equal("z", obj.MustString("x.y"), "escaped dot key is set")
+
+	err = obj.DelPath("a.1")
+	a.Nil(err, "err is nil")
+	obj.MustDelPath(`x\.y`)
+	a.Equal(`{"a":[{"b":{"c":1,"d":{"e":true}}}]}`, obj.MustToString(), "str is correct value")
+
+	obj.MustSetPath("", 1)
+	a.Equal(1, obj.MustInt(), "empty path is the root")
+}
+
+func Test_SetMany(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`{"name":"ann","tags":["a"]}`)
+	a.Nil(err, "err is nil")
+
+	err = obj.SetMany(map[string]interface{}{
+		"name":         "bob",
+		"address.city": "york",
+		"address":      map[string]interface{}{"line1": "1 high st"},
+		"tags.1":       "b",
+	})
+	a.Nil(err, "err is nil")
+	a.Equal(`{"address":{"city":"york","line1":"1 high st"},"name":"bob","tags":["a","b"]}`, obj.MustToString(), "updates are applied in sorted order")
+
+	before := obj.MustToString()
+	err = obj.SetMany(map[string]interface{}{"a": 1, "name.first": "x", "z": 2})
+	a.Equal([]interface{}{"name"}, err.(*PathError).FoundPath, "error FoundPath is correct")
+	a.Equal(before, obj.MustToString(), "applied updates are rolled back")
+
+	a.Equal("c", obj.MustSetMany(map[string]interface{}{"tags.0": "c"}).MustString("tags", 0), "must returns the updated json")
+	a.Panics(func() { obj.MustSetMany(map[string]interface{}{"name.x": 1}) }, "panics on error")
+}
@@ -0,0 +1,140 @@
+package json
+
+import (
+	"fmt"
+	"github.com/0xor1/panic"
+	"strconv"
+	"strings"
+)
+
+var (
+	pointerEscaper   = strings.NewReplacer("~", "~0", "/", "~1")
+	pointerUnescaper = strings.NewReplacer("~1", "/", "~0", "~")
+)
+
+// PointerOf renders `path` as an RFC 6901 JSON Pointer, escaping `~` as `~0`
+// and `/` as `~1` in string segments
+//		PointerOf([]interface{}{"a/b", 1}) => "/a~1b/1"
+func PointerOf(path []interface{}) string {
+	b := strings.Builder{}
+	for _, p := range path {
+		b.WriteByte('/')
+		switch seg := p.(type) {
+		case string:
+			b.WriteString(pointerEscaper.Replace(seg))
+		case int:
+			b.WriteString(strconv.Itoa(seg))
+		default:
+			b.WriteString(pointerEscaper.Replace(fmt.Sprint(seg)))
+		}
+	}
+	return b.String()
+}
+
+// ParsePointer parses an RFC 6901 JSON Pointer into a path, tokens that are valid
+// array indices (`0` or digits without a leading zero) become ints and all others
+// become strings. The empty pointer results in an empty path.
+func ParsePointer(ptr string) ([]interface{}, error) {
+	tokens, err := pointerTokens(ptr)
+	if err != nil {
+		return nil, err
+	}
+	path := make([]interface{}, 0, len(tokens))
+	for _, token := range tokens {
+		if i, ok := pointerIndex(token); ok {
+			path = append(path, i)
+		} else {
+			path = append(path, token)
+		}
+	}
+	return path, nil
+}
+
+// MustParsePointer is a call to ParsePointer with a panic on none nil error
+func MustParsePointer(ptr string) []interface{} {
+	path, err := ParsePointer(ptr)
+	panic.IfNotNil(err)
+	return path
+}
+
+// GetPointer is a call to Get with the path referenced by the RFC 6901 JSON Pointer
+// `ptr`, each token is resolved against the value it is applied to so a token of
+// digits is a key of an object and an index of an array. The empty pointer
+// references the whole document.
+func (j *Json) GetPointer(ptr string) (*Json, error) {
+	path, err := j.pointerPath(ptr)
+	if err != nil {
+		return nil, err
+	}
+	return j.Get(path...)
+}
+
+// MustGetPointer is a call to GetPointer with a panic on none nil error
+func (j *Json) MustGetPointer(ptr string) *Json {
+	js, err := j.GetPointer(ptr)
+	panic.IfNotNil(err)
+	return js
+}
+
+// pointerPath converts `ptr` to a path, typing each token by the container it is
+// applied to in `j`, tokens beyond the existing data are typed as by ParsePointer
+func (j *Json) pointerPath(ptr string) ([]interface{}, error) {
+	tokens, err := pointerTokens(ptr)
+	if err != nil {
+		return nil, err
+	}
+	path := make([]interface{}, 0, len(tokens))
+	cur := j.data
+	for _, token := range tokens {
+		var seg interface{} = token
+		i, isIndex := pointerIndex(token)
+		switch c := cur.(type) {
+		case map[string]interface{}:
+			cur = c[token]
+		case []interface{}:
+			cur = nil
+			if isIndex {
+				seg = i
+				if i < len(c) {
+					cur = c[i]
+				}
+			}
+		default:
+			cur = nil
+			if isIndex {
+				seg = i
+			}
+		}
+		path = append(path, seg)
+	}
+	return path, nil
+}
+
+// pointerTokens splits `ptr` into its unescaped reference tokens
+func pointerTokens(ptr string) ([]string, error) {
+	if ptr == "" {
+		return []string{}, nil
+	}
+	if ptr[0] != '/' {
+		return nil, fmt.Errorf("invalid JSON pointer %q: must be empty or start with /", ptr)
+	}
+	tokens := strings.Split(ptr[1:], "/")
+	for i, token := range tokens {
+		for k := 0; k < len(token); k++ {
+			if token[k] == '~' && (k+1 == len(token) || (token[k+1] != '0' && token[k+1] != '1')) {
+				return nil, fmt.Errorf("invalid JSON pointer %q: ~ must be followed by 0 or 1", ptr)
+			}
+		}
+		tokens[i] = pointerUnescaper.Replace(token)
+	}
+	return tokens, nil
+}
+
+// pointerIndex reports whether `token` is a valid array index and returns its value
+func pointerIndex(token string) (int, bool) {
+	if !isDigits(token) || (len(token) > 1 && token[0] == '0') {
+		return 0, false
+	}
+	i, err := strconv.Atoi(token)
+	return i, err == nil
+}
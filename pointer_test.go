@@ -0,0 +1,64 @@
+package json
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_PointerOf(t *testing.T) {
+	a := assert.New(t)
+
+	a.Equal("", PointerOf(nil), "ptr is correct")
+	a.Equal("/a~1b/1/m~0n/", PointerOf([]interface{}{"a/b", 1, "m~n", ""}), "ptr is correct")
+}
+
+func Test_ParsePointer(t *testing.T) {
+	a := assert.New(t)
+
+	path, err := ParsePointer("/a~1b/1/m~0n//01/-/~01")
+	a.Nil(err, "err is nil")
+	a.Equal([]interface{}{"a/b", 1, "m~n", "", "01", "-", "~1"}, path, "path is correct")
+	a.Equal([]interface{}{}, MustParsePointer(""), "path is empty")
+	a.Equal(path, MustParsePointer(PointerOf(path)), "path round trips")
+}
+
+func Test_ParsePointer_Error(t *testing.T) {
+	a := assert.New(t)
+
+	path, err := ParsePointer("a/b")
+	a.Nil(path, "path is nil")
+	a.Equal(`invalid JSON pointer "a/b": must be empty or start with /`, err.Error(), "error message is correct")
+
+	path, err = ParsePointer("/a~2")
+	a.Nil(path, "path is nil")
+	a.Equal(`invalid JSON pointer "/a~2": ~ must be followed by 0 or 1`, err.Error(), "error message is correct")
+}
+
+func Test_GetPointer(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`{"a":[{"b":1}],"c/d":2,"e~f":3,"0":{"1":4},"":5}`)
+	a.Nil(err, "err is nil")
+
+	js, err := obj.GetPointer("/a/0/b")
+	a.Nil(err, "err is nil")
+	a.Equal(1, js.MustInt(), "nested value is correct")
+	a.Equal(2, obj.MustGetPointer("/c~1d").MustInt(), "~1 is unescaped")
+	a.Equal(3, obj.MustGetPointer("/e~0f").MustInt(), "~0 is unescaped")
+	a.Equal(4, obj.MustGetPointer("/0/1").MustInt(), "digit tokens are object keys")
+	a.Equal(5, obj.MustGetPointer("/").MustInt(), "empty token is the empty key")
+	a.Equal(obj.MustToString(), obj.MustGetPointer("").MustToString(), "empty pointer is the whole document")
+
+	_, err = obj.GetPointer("/a/1/b")
+	a.Equal([]interface{}{"a"}, err.(*PathError).FoundPath, "error FoundPath is correct")
+	a.Equal([]interface{}{1, "b"}, err.(*PathError).MissingPath, "error MissingPath is correct")
+	_, err = obj.GetPointer("/a/-")
+	a.Equal([]interface{}{"-"}, err.(*PathError).MissingPath, "error MissingPath is correct")
+	_, err = obj.GetPointer("/x/0")
+	a.Equal([]interface{}{"x", 0}, err.(*PathError).MissingPath, "error MissingPath is correct")
+
+	_, err = obj.GetPointer("a/0")
+	a.Equal(`invalid JSON pointer "a/0": must be empty or start with /`, err.Error(), "error message is correct")
+	_, err = obj.GetPointer("/a~2")
+	a.Equal(`invalid JSON pointer "/a~2": ~ must be followed by 0 or 1`, err.Error(), "error message is correct")
+}
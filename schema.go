@@ -0,0 +1,107 @@
+package json
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/0xor1/panic"
+)
+
+// ExampleFromSchema generates a minimal document satisfying the JSON Schema `schema`.
+// The `default`, `enum`, `type`, `properties` and `required` keywords are
+// supported: a `default` is used when present, then the first `enum` value, otherwise
+// the zero value of the first `type` listed, where objects are populated with only
+// their `required` properties and arrays are left empty.
+func ExampleFromSchema(schema *Json) (*Json, error) {
+	data, err := exampleFromSchema(schema.data, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &Json{data: data}, nil
+}
+
+// MustExampleFromSchema is a call to ExampleFromSchema with a panic on none nil error
+func MustExampleFromSchema(schema *Json) *Json {
+	js, err := ExampleFromSchema(schema)
+	panic.IfNotNil(err)
+	return js
+}
+
+func exampleFromSchema(schema interface{}, path []interface{}) (interface{}, error) {
+	if b, ok := schema.(bool); ok {
+		if b {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("schema at %q: false schema has no valid value", dottedPath(path))
+	}
+	s, ok := schema.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("schema at %q: schema must be an object or bool", dottedPath(path))
+	}
+	if def, exists := s["default"]; exists {
+		return cloneData(def), nil
+	}
+	if enum, exists := s["enum"]; exists {
+		if vals, ok := enum.([]interface{}); ok && len(vals) > 0 {
+			return cloneData(vals[0]), nil
+		}
+		return nil, fmt.Errorf("schema at %q: enum must be a none empty array", dottedPath(path))
+	}
+	typ, err := schemaType(s, path)
+	if err != nil {
+		return nil, err
+	}
+	switch typ {
+	case "object":
+		obj := map[string]interface{}{}
+		props, _ := s["properties"].(map[string]interface{})
+		required, _ := s["required"].([]interface{})
+		for _, r := range required {
+			name, ok := r.(string)
+			if !ok {
+				return nil, fmt.Errorf("schema at %q: required must only contain strings", dottedPath(path))
+			}
+			var propSchema interface{} = true
+			if p, exists := props[name]; exists {
+				propSchema = p
+			}
+			if obj[name], err = exampleFromSchema(propSchema, append(path, name)); err != nil {
+				return nil, err
+			}
+		}
+		return obj, nil
+	case "array":
+		return []interface{}{}, nil
+	case "string":
+		return "", nil
+	case "number", "integer":
+		return json.Number("0"), nil
+	case "boolean":
+		return false, nil
+	case "null":
+		return nil, nil
+	}
+	return nil, fmt.Errorf("schema at %q: unsupported type %q", dottedPath(path), typ)
+}
+
+// schemaType returns the first type named by the schemas `type` keyword, or infers
+// "object" when `properties` or `required` are present, and "null" otherwise
+func schemaType(s map[string]interface{}, path []interface{}) (string, error) {
+	switch t := s["type"].(type) {
+	case string:
+		return t, nil
+	case []interface{}:
+		if len(t) > 0 {
+			if str, ok := t[0].(string); ok {
+				return str, nil
+			}
+		}
+	case nil:
+		_, hasProps := s["properties"]
+		_, hasRequired := s["required"]
+		if hasProps || hasRequired {
+			return "object", nil
+		}
+		return "null", nil
+	}
+	return "", fmt.Errorf("schema at %q: type must be a string or a none empty array of strings", dottedPath(path))
+}
@@ -0,0 +1,48 @@
+package json
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_ExampleFromSchema(t *testing.T) {
+	a := assert.New(t)
+
+	schema, err := FromString(`{
+		"type": "object",
+		"required": ["name", "age", "tags", "role", "address", "active", "nick"],
+		"properties": {
+			"name": {"type": "string"},
+			"age": {"type": ["integer", "null"]},
+			"tags": {"type": "array", "items": {"type": "string"}},
+			"role": {"type": "string", "enum": ["admin", "user"]},
+			"address": {
+				"type": "object",
+				"required": ["city"],
+				"properties": {"city": {"type": "string", "default": "London"}, "zip": {"type": "string"}}
+			},
+			"active": {"type": "boolean"},
+			"optional": {"type": "string"}
+		}
+	}`)
+	a.Nil(err, "err is nil")
+
+	obj, err := ExampleFromSchema(schema)
+	a.Nil(err, "err is nil")
+	MustExampleFromSchema(schema)
+
+	str, err := obj.ToString()
+	a.Nil(err, "err is nil")
+	a.Equal(`{"active":false,"address":{"city":"London"},"age":0,"name":"","nick":null,"role":"admin","tags":[]}`, str, "str is correct value")
+}
+
+func Test_ExampleFromSchema_Error(t *testing.T) {
+	a := assert.New(t)
+
+	schema, err := FromString(`{"required":["a"],"properties":{"a":{"type":"date"}}}`)
+	a.Nil(err, "err is nil")
+
+	obj, err := ExampleFromSchema(schema)
+	a.Nil(obj, "obj is nil")
+	a.Equal(`schema at "a": unsupported type "date"`, err.Error(), "error message is correct")
+}
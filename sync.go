@@ -0,0 +1,220 @@
+package json
+
+import (
+	"github.com/0xor1/panic"
+	"sync"
+	"time"
+)
+
+// SyncJson guards a `Json` with a `sync.RWMutex` so that it can be read from and
+// written to by many goroutines at once, reads are made under the read lock and
+// writes under the write lock. Values that reference the underlying data, such as
+// those returned by Get, Map and Slice, are deep copies so they can be used after the
+// lock is released, and values written by Set and Merge are deep copied so the caller
+// may go on using its own. The wrapped `Json` must only be used through SyncJson, use
+// View and Update to make several calls under one lock.
+type SyncJson struct {
+	mu sync.RWMutex
+	j  *Json
+}
+
+// NewSync returns a new `SyncJson` guarding `j`, which must not be used directly
+// after this call
+//		cfg := json.NewSync(json.MustFromFile("config.json"))
+func NewSync(j *Json) *SyncJson {
+	return &SyncJson{j: j}
+}
+
+// View calls `fn` with the wrapped `Json` under the read lock, `fn` must not modify
+// it or retain any of its values after returning
+func (s *SyncJson) View(fn func(j *Json) error) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return fn(s.j)
+}
+
+// Update calls `fn` with the wrapped `Json` under the write lock, for changes that
+// must be made together, `fn` must not retain any of its values after returning
+//		cfg.Update(func(j *json.Json) error { return j.Set("n", j.IntOrDefault(0, "n")+1) })
+func (s *SyncJson) Update(fn func(j *Json) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return fn(s.j)
+}
+
+// Get is a call to Get under the read lock returning a deep copy of the value
+func (s *SyncJson) Get(path ...interface{}) (*Json, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	js, err := s.j.Get(path...)
+	if err != nil {
+		return nil, err
+	}
+	return js.Clone(), nil
+}
+
+// MustGet is a call to Get with a panic on none nil error
+func (s *SyncJson) MustGet(path ...interface{}) *Json {
+	js, err := s.Get(path...)
+	panic.IfNotNil(err)
+	return js
+}
+
+// Exists is a call to Exists under the read lock
+func (s *SyncJson) Exists(path ...interface{}) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.j.Exists(path...)
+}
+
+// Interface is a call to Interface under the read lock returning a deep copy of the value
+func (s *SyncJson) Interface(path ...interface{}) (interface{}, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	v, err := s.j.Interface(path...)
+	if err != nil {
+		return nil, err
+	}
+	return cloneData(v), nil
+}
+
+// Map is a call to Map under the read lock returning a deep copy of the value
+func (s *SyncJson) Map(path ...interface{}) (map[string]interface{}, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	m, err := s.j.Map(path...)
+	if err != nil {
+		return nil, err
+	}
+	return cloneData(m).(map[string]interface{}), nil
+}
+
+// Slice is a call to Slice under the read lock returning a deep copy of the value
+func (s *SyncJson) Slice(path ...interface{}) ([]interface{}, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	a, err := s.j.Slice(path...)
+	if err != nil {
+		return nil, err
+	}
+	return cloneData(a).([]interface{}), nil
+}
+
+// Bool is a call to Bool under the read lock
+func (s *SyncJson) Bool(path ...interface{}) (bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.j.Bool(path...)
+}
+
+// BoolOrDefault is a call to BoolOrDefault under the read lock
+func (s *SyncJson) BoolOrDefault(def bool, path ...interface{}) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.j.BoolOrDefault(def, path...)
+}
+
+// String is a call to String under the read lock
+func (s *SyncJson) String(path ...interface{}) (string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.j.String(path...)
+}
+
+// StringOrDefault is a call to StringOrDefault under the read lock
+func (s *SyncJson) StringOrDefault(def string, path ...interface{}) string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.j.StringOrDefault(def, path...)
+}
+
+// Int is a call to Int under the read lock
+func (s *SyncJson) Int(path ...interface{}) (int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.j.Int(path...)
+}
+
+// IntOrDefault is a call to IntOrDefault under the read lock
+func (s *SyncJson) IntOrDefault(def int, path ...interface{}) int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.j.IntOrDefault(def, path...)
+}
+
+// Int64 is a call to Int64 under the read lock
+func (s *SyncJson) Int64(path ...interface{}) (int64, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.j.Int64(path...)
+}
+
+// Float64 is a call to Float64 under the read lock
+func (s *SyncJson) Float64(path ...interface{}) (float64, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.j.Float64(path...)
+}
+
+// Time is a call to Time under the read lock
+func (s *SyncJson) Time(path ...interface{}) (time.Time, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.j.Time(path...)
+}
+
+// Duration is a call to Duration under the read lock
+func (s *SyncJson) Duration(path ...interface{}) (time.Duration, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.j.Duration(path...)
+}
+
+// ToBytes is a call to ToBytes under the read lock
+func (s *SyncJson) ToBytes() ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.j.ToBytes()
+}
+
+// Set is a call to Set under the write lock with a deep copy of the value
+func (s *SyncJson) Set(pathPartsThenValue ...interface{}) error {
+	args := append([]interface{}{}, pathPartsThenValue...)
+	if n := len(args); n > 0 {
+		args[n-1] = cloneData(args[n-1])
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.j.Set(args...)
+}
+
+// MustSet is a call to Set with a panic on none nil error
+func (s *SyncJson) MustSet(pathPartsThenValue ...interface{}) *SyncJson {
+	panic.IfNotNil(s.Set(pathPartsThenValue...))
+	return s
+}
+
+// Del is a call to Del under the write lock
+func (s *SyncJson) Del(path ...interface{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.j.Del(path...)
+}
+
+// MustDel is a call to Del with a panic on none nil error
+func (s *SyncJson) MustDel(path ...interface{}) {
+	panic.IfNotNil(s.Del(path...))
+}
+
+// Merge is a call to Merge under the write lock, which copies the values from `other`
+func (s *SyncJson) Merge(other *Json) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.j.Merge(other)
+}
+
+// MustMerge is a call to Merge with a panic on none nil error
+func (s *SyncJson) MustMerge(other *Json) *SyncJson {
+	panic.IfNotNil(s.Merge(other))
+	return s
+}
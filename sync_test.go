@@ -0,0 +1,103 @@
+package json
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+	"time"
+)
+
+func Test_SyncJson(t *testing.T) {
+	a := assert.New(t)
+
+	s := NewSync(MustFromString(`{"a":{"b":[1,"x",true,1.5]},"t":"2020-01-02T03:04:05Z","d":"1s"}`))
+
+	js, err := s.Get("a")
+	a.Nil(err, "err is nil")
+	js.MustSet("b", 0, 2)
+	a.Equal(1, s.IntOrDefault(0, "a", "b", 0), "get returns a copy")
+	m, err := s.Map("a")
+	a.Nil(err, "err is nil")
+	m["c"] = 1
+	a.False(s.Exists("a", "c"), "map returns a copy")
+	arr, err := s.Slice("a", "b")
+	a.Nil(err, "err is nil")
+	arr[0] = 2
+	v, err := s.Interface("a", "b")
+	a.Nil(err, "err is nil")
+	a.Equal(1, FromInterface(v).MustInt(0), "slice and interface return copies")
+
+	a.Equal("x", s.StringOrDefault("", "a", "b", 1), "string is correct")
+	a.True(s.BoolOrDefault(false, "a", "b", 2), "bool is correct")
+	f, err := s.Float64("a", "b", 3)
+	a.Nil(err, "err is nil")
+	a.Equal(1.5, f, "float64 is correct")
+	tm, err := s.Time("t")
+	a.Nil(err, "err is nil")
+	a.Equal(time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC), tm, "time is correct")
+	d, err := s.Duration("d")
+	a.Nil(err, "err is nil")
+	a.Equal(time.Second, d, "duration is correct")
+
+	s.MustSet("a", "c", "y")
+	str, err := s.String("a", "c")
+	a.Nil(err, "err is nil")
+	a.Equal("y", str, "set value is read")
+	s.MustDel("a", "c")
+	val := map[string]interface{}{"f": 1}
+	s.MustSet("v", val)
+	val["f"] = 2
+	a.Equal(1, s.IntOrDefault(0, "v", "f"), "set stores a copy")
+	s.MustDel("v")
+	s.MustMerge(MustFromString(`{"a":{"e":1},"t":null}`))
+	b, err := s.ToBytes()
+	a.Nil(err, "err is nil")
+	a.Equal(`{"a":{"b":[1,"x",true,1.5],"e":1},"d":"1s","t":null}`, string(b), "writes are applied")
+
+	err = s.Update(func(j *Json) error {
+		return j.Set("n", j.IntOrDefault(0, "n")+1)
+	})
+	a.Nil(err, "err is nil")
+	a.Equal(errors.New("x"), s.View(func(j *Json) error { return errors.New("x") }), "view returns fn error")
+	a.Equal(1, s.MustGet("n").MustInt(), "update is applied")
+
+	_, err = s.Get("z")
+	a.NotNil(err, "err is not nil")
+	a.Panics(func() { s.MustGet("z") }, "panics on error")
+	a.Panics(func() { s.MustSet("a", "b", 0, "c", 1) }, "panics on error")
+}
+
+func Test_SyncJson_Concurrent(t *testing.T) {
+	a := assert.New(t)
+
+	s := NewSync(MustFromString(`{"n":0,"items":[]}`))
+	wg := sync.WaitGroup{}
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			for k := 0; k < 100; k++ {
+				s.MustSet("w", i, k)
+				s.Update(func(j *Json) error {
+					return j.Set("n", j.IntOrDefault(0, "n")+1)
+				})
+				s.MustMerge(FromInterface(map[string]interface{}{"m": map[string]interface{}{"k": k}}))
+				s.Del("w")
+			}
+		}(i)
+		go func() {
+			defer wg.Done()
+			for k := 0; k < 100; k++ {
+				s.IntOrDefault(0, "n")
+				s.Exists("w")
+				if js, err := s.Get("m"); err == nil {
+					js.MustSet("k", -1)
+				}
+				s.ToBytes()
+			}
+		}()
+	}
+	wg.Wait()
+	a.Equal(800, s.MustGet("n").MustInt(), "every update is applied")
+}
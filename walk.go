@@ -0,0 +1,430 @@
+package json
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/0xor1/panic"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// SkipChildren may be returned from the function passed to Walk to skip the children
+// of the current node without stopping the rest of the walk
+var SkipChildren = errors.New("skip children")
+
+// Walk performs a depth first traversal of the document calling `fn` for every node,
+// parents before their children and object keys in sorted order, with a copy of its
+// path that `fn` may retain. If `fn` returns SkipChildren the children of that node
+// are not visited, any other error stops the walk and is returned.
+func (j *Json) Walk(fn func(path []interface{}, value *Json) error) error {
+	tmp, err := j.Get()
+	if err != nil {
+		return err
+	}
+	return walk(tmp.data, nil, func(path []interface{}, v interface{}) error {
+		return fn(append([]interface{}{}, path...), j.wrap(v))
+	})
+}
+
+// MustWalk is a call to Walk with a panic on none nil error
+func (j *Json) MustWalk(fn func(path []interface{}, value *Json) error) {
+	panic.IfNotNil(j.Walk(fn))
+}
+
+// walk performs a depth first traversal of `data`, calling `fn` for every node
+// with its path, object keys are visited in sorted order. `path` is reused
+// between calls so `fn` must copy it if it needs to retain it. If `fn` returns
+// SkipChildren the node's children are not visited.
+func walk(data interface{}, path []interface{}, fn func(path []interface{}, v interface{}) error) error {
+	if err := fn(path, data); err == SkipChildren {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	switch v := data.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if err := walk(v[k], append(path, k), fn); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for i, e := range v {
+			if err := walk(e, append(path, i), fn); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// transform performs a depth first traversal of `data`, replacing every node, children
+// before their parents, with the result of calling `fn` with its path and value.
+// Objects and arrays are updated in place. `path` is reused between calls so `fn`
+// must copy it if it needs to retain it.
+func transform(data interface{}, path []interface{}, fn func(path []interface{}, v interface{}) (interface{}, error)) (interface{}, error) {
+	var err error
+	switch v := data.(type) {
+	case map[string]interface{}:
+		for k, e := range v {
+			if v[k], err = transform(e, append(path, k), fn); err != nil {
+				return nil, err
+			}
+		}
+	case []interface{}:
+		for i, e := range v {
+			if v[i], err = transform(e, append(path, i), fn); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return fn(path, data)
+}
+
+// flatten returns a map of dotted paths to every leaf in `data`, where a leaf is
+// any value that is not a populated object or array
+func flatten(data interface{}) map[string]interface{} {
+	flat := map[string]interface{}{}
+	walk(data, nil, func(path []interface{}, v interface{}) error {
+		switch c := v.(type) {
+		case map[string]interface{}:
+			if len(c) > 0 {
+				return nil
+			}
+		case []interface{}:
+			if len(c) > 0 {
+				return nil
+			}
+		}
+		flat[dottedPath(path)] = v
+		return nil
+	})
+	return flat
+}
+
+// isScalar reports whether `v` is a JSON null, bool, string or number
+func isScalar(v interface{}) bool {
+	switch v.(type) {
+	case nil, bool, string, json.Number, float32, float64, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return true
+	}
+	return false
+}
+
+// ToLogFields flattens the document into a single level map of dotted paths to
+// scalar values, suitable for use as structured logger fields, any none scalar
+// leaves (such as empty objects and arrays) are JSON encoded to strings
+//		{"a":{"b":[1,{}]}} => {"a.b.0": 1, "a.b.1": "{}"}
+func (j *Json) ToLogFields() map[string]interface{} {
+	fields := flatten(j.data)
+	for k, v := range fields {
+		if !isScalar(v) {
+			if b, err := json.Marshal(v); err == nil {
+				fields[k] = string(b)
+			}
+		}
+	}
+	return fields
+}
+
+// ToFlatJSONL treats the value at `arrayPath` as an array of objects and encodes each
+// one flattened to dotted paths, as described by ToLogFields but with empty objects
+// and arrays kept as is, on its own newline terminated line
+//		[{"a":{"b":1}},{"a":{"c":[]}}] => {"a.b":1}\n{"a.c":[]}\n
+func (j *Json) ToFlatJSONL(arrayPath []interface{}) ([]byte, error) {
+	objs, err := j.MapSlice(arrayPath...)
+	if err != nil {
+		return nil, err
+	}
+	buf := &bytes.Buffer{}
+	for i, obj := range objs {
+		flat := map[string]interface{}{}
+		if len(obj) > 0 {
+			flat = flatten(obj)
+		}
+		b, err := json.Marshal(flat)
+		if err != nil {
+			return nil, fmt.Errorf("element %d: %w", i, err)
+		}
+		buf.Write(b)
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes(), nil
+}
+
+// MustToFlatJSONL is a call to ToFlatJSONL with a panic on none nil error
+func (j *Json) MustToFlatJSONL(arrayPath []interface{}) []byte {
+	b, err := j.ToFlatJSONL(arrayPath)
+	panic.IfNotNil(err)
+	return b
+}
+
+// Flatten returns a single level map of the dotted paths, as described by GetPath,
+// of every scalar in the document to its value. Empty objects and arrays are kept
+// as values so the document can be rebuilt with Unflatten.
+//		{"a":[{"b":1},{}]} => {"a.0.b": 1, "a.1": {}}
+func (j *Json) Flatten() (map[string]interface{}, error) {
+	tmp, err := j.Get()
+	if err != nil {
+		return nil, err
+	}
+	flat := flatten(tmp.data)
+	for k, v := range flat {
+		flat[k] = cloneData(v)
+	}
+	return flat, nil
+}
+
+// MustFlatten is a call to Flatten with a panic on none nil error
+func (j *Json) MustFlatten() map[string]interface{} {
+	flat, err := j.Flatten()
+	panic.IfNotNil(err)
+	return flat
+}
+
+// Unflatten returns a pointer to a new `Json` object built from a map of dotted
+// paths to values as returned by Flatten, the root is an array if the first segment
+// of the paths is an int index and an object otherwise
+func Unflatten(m map[string]interface{}) (*Json, error) {
+	type entry struct {
+		path []interface{}
+		val  interface{}
+	}
+	entries := make([]entry, 0, len(m))
+	for k, v := range m {
+		entries = append(entries, entry{parseDottedPath(k), v})
+	}
+	// order indices numerically so that slices are grown before being descended into
+	sort.Slice(entries, func(x, y int) bool {
+		return comparePaths(entries[x].path, entries[y].path) < 0
+	})
+	j := &Json{data: map[string]interface{}{}}
+	if len(entries) > 0 && len(entries[0].path) > 0 {
+		if _, ok := entries[0].path[0].(int); ok {
+			j.data = []interface{}{}
+		}
+	}
+	for _, e := range entries {
+		if err := j.setAt(e.path, cloneData(e.val)); err != nil {
+			return nil, err
+		}
+	}
+	return j, nil
+}
+
+// MustUnflatten is a call to Unflatten with a panic on none nil error
+func MustUnflatten(m map[string]interface{}) *Json {
+	js, err := Unflatten(m)
+	panic.IfNotNil(err)
+	return js
+}
+
+// comparePaths orders paths segment by segment, ints numerically before strings
+func comparePaths(a, b []interface{}) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		ai, aInt := a[i].(int)
+		bi, bInt := b[i].(int)
+		switch {
+		case aInt && bInt:
+			if ai != bi {
+				return ai - bi
+			}
+		case aInt != bInt:
+			if aInt {
+				return -1
+			}
+			return 1
+		default:
+			if c := strings.Compare(fmt.Sprint(a[i]), fmt.Sprint(b[i])); c != 0 {
+				return c
+			}
+		}
+	}
+	return len(a) - len(b)
+}
+
+// Skeleton returns a clone of the document with every scalar replaced by a string
+// describing its kind, and the rune count of strings, keeping all keys and array
+// lengths so the shape of a document can be shared without its values
+//		{"a":["secret",1,true,null]} => {"a":["<string:6>","<number>","<bool>","<null>"]}
+func (j *Json) Skeleton() *Json {
+	data, _ := transform(cloneData(j.data), nil, func(path []interface{}, v interface{}) (interface{}, error) {
+		switch c := v.(type) {
+		case map[string]interface{}, []interface{}:
+			return v, nil
+		case string:
+			return fmt.Sprintf("<string:%d>", utf8.RuneCountInString(c)), nil
+		}
+		kind, _ := kindOf(v)
+		return "<" + kind.String() + ">", nil
+	})
+	return j.wrap(data)
+}
+
+// NormalizeMaps recursively converts any `map[interface{}]interface{}`, as produced by
+// some YAML and msgpack decoders, into a `map[string]interface{}` so that it can be
+// navigated. Keys are stringified with fmt.Sprint, an error is returned if a key is
+// not a string, bool or number, or if two keys stringify to the same value.
+func (j *Json) NormalizeMaps() error {
+	data, err := normalizeMaps(j.data, nil)
+	if err != nil {
+		return err
+	}
+	j.data = data
+	return nil
+}
+
+// MustNormalizeMaps is a call to NormalizeMaps with a panic on none nil error
+func (j *Json) MustNormalizeMaps() *Json {
+	panic.IfNotNil(j.NormalizeMaps())
+	return j
+}
+
+func normalizeMaps(data interface{}, path []interface{}) (interface{}, error) {
+	var err error
+	switch v := data.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			if !isScalar(k) || k == nil {
+				return nil, fmt.Errorf("none stringifiable key %v of type %T at %q", k, k, dottedPath(path))
+			}
+			key := fmt.Sprint(k)
+			if _, exists := m[key]; exists {
+				return nil, fmt.Errorf("duplicate key %q at %q", key, dottedPath(path))
+			}
+			if m[key], err = normalizeMaps(e, append(path, key)); err != nil {
+				return nil, err
+			}
+		}
+		return m, nil
+	case map[string]interface{}:
+		for k, e := range v {
+			if v[k], err = normalizeMaps(e, append(path, k)); err != nil {
+				return nil, err
+			}
+		}
+	case []interface{}:
+		for i, e := range v {
+			if v[i], err = normalizeMaps(e, append(path, i)); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return data, nil
+}
+
+// Head returns a copy of the document where every array is truncated to its first `n`
+// elements and every object to its first `n` keys in sorted order, recursively, so
+// that large documents can be inspected at a glance
+func (j *Json) Head(n int) *Json {
+	return &Json{data: head(j.data, n), timeLayout: j.timeLayout}
+}
+
+func head(data interface{}, n int) interface{} {
+	if n < 0 {
+		n = 0
+	}
+	switch v := data.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		if len(keys) > n {
+			keys = keys[:n]
+		}
+		m := make(map[string]interface{}, len(keys))
+		for _, k := range keys {
+			m[k] = head(v[k], n)
+		}
+		return m
+	case []interface{}:
+		if len(v) > n {
+			v = v[:n]
+		}
+		a := make([]interface{}, len(v))
+		for i, e := range v {
+			a[i] = head(e, n)
+		}
+		return a
+	}
+	return data
+}
+
+// GrepValues returns the paths of every string value containing `substr`, in the
+// order a depth first traversal with sorted object keys visits them
+func (j *Json) GrepValues(substr string, caseInsensitive bool) [][]interface{} {
+	if caseInsensitive {
+		substr = strings.ToLower(substr)
+	}
+	paths := [][]interface{}{}
+	walk(j.data, nil, func(path []interface{}, v interface{}) error {
+		if str, ok := v.(string); ok {
+			if caseInsensitive {
+				str = strings.ToLower(str)
+			}
+			if strings.Contains(str, substr) {
+				paths = append(paths, append([]interface{}{}, path...))
+			}
+		}
+		return nil
+	})
+	return paths
+}
+
+// ReplaceStrings replaces every string value in the document with the result of
+// `re.ReplaceAllString(value, repl)`, returning the number of values changed
+func (j *Json) ReplaceStrings(re *regexp.Regexp, repl string) int {
+	n := 0
+	j.data, _ = transform(j.data, nil, func(_ []interface{}, v interface{}) (interface{}, error) {
+		if str, ok := v.(string); ok {
+			if replaced := re.ReplaceAllString(str, repl); replaced != str {
+				n++
+				return replaced, nil
+			}
+		}
+		return v, nil
+	})
+	return n
+}
+
+// PrecisionRisks returns the paths of every `json.Number` value that would not
+// survive conversion to a float64, that is integers beyond 2^53, decimals with more
+// significant digits than a float64 holds, and values out of the float64 range
+func (j *Json) PrecisionRisks() [][]interface{} {
+	paths := [][]interface{}{}
+	walk(j.data, nil, func(path []interface{}, v interface{}) error {
+		if n, ok := v.(json.Number); ok && !float64RoundTrips(n) {
+			paths = append(paths, append([]interface{}{}, path...))
+		}
+		return nil
+	})
+	return paths
+}
+
+// float64RoundTrips reports whether the shortest formatting of `n` as a float64
+// has exactly the same value as `n`
+func float64RoundTrips(n json.Number) bool {
+	f, err := strconv.ParseFloat(string(n), 64)
+	if err != nil {
+		return false
+	}
+	orig, ok := numberRat(n)
+	if !ok {
+		return false
+	}
+	back, _ := numberRat(json.Number(strconv.FormatFloat(f, 'g', -1, 64)))
+	return orig.Cmp(back) == 0
+}
@@ -0,0 +1,249 @@
+package json
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"regexp"
+	"testing"
+)
+
+func Test_Walk(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`{"b":[1,{"c":true}],"a":{"secret":{"x":1}},"d":"e"}`)
+	a.Nil(err, "err is nil")
+
+	paths := [][]interface{}{}
+	err = obj.Walk(func(path []interface{}, value *Json) error {
+		paths = append(paths, path)
+		if len(path) == 1 && path[0] == "a" {
+			return SkipChildren
+		}
+		return nil
+	})
+	a.Nil(err, "err is nil")
+	a.Equal([][]interface{}{
+		{},
+		{"a"},
+		{"b"},
+		{"b", 0},
+		{"b", 1},
+		{"b", 1, "c"},
+		{"d"},
+	}, paths, "paths are retained copies in depth first order")
+
+	count := 0
+	err = obj.Walk(func(path []interface{}, value *Json) error {
+		if count++; value.MustType() == KindBool {
+			return errors.New("found bool")
+		}
+		return nil
+	})
+	a.Equal("found bool", err.Error(), "fn error is returned")
+	a.Equal(8, count, "walk stops at the first error")
+
+	obj.MustWalk(func(path []interface{}, value *Json) error { return SkipChildren })
+	err = obj.Maybe("z").Walk(func([]interface{}, *Json) error { return nil })
+	a.NotNil(err, "err is not nil")
+}
+
+func Test_ToLogFields(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`{"a":{"b":[1,{},"x"]},"c.d":null,"e":[]}`)
+	a.Nil(err, "err is nil")
+
+	fields := obj.ToLogFields()
+	a.Equal(map[string]interface{}{
+		"a.b.0": obj.MustInterface("a", "b", 0),
+		"a.b.1": "{}",
+		"a.b.2": "x",
+		`c\.d`:  nil,
+		"e":     "[]",
+	}, fields, "fields is correct")
+}
+
+func Test_ToLogFields_Scalar(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`true`)
+	a.Nil(err, "err is nil")
+
+	a.Equal(map[string]interface{}{"": true}, obj.ToLogFields(), "fields is correct")
+}
+
+func Test_ToFlatJSONL(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`{"records":[{"a":{"b":1,"c":[true,{}]}},{"d.e":null,"f":[]},{}],"x":[1]}`)
+	a.Nil(err, "err is nil")
+
+	b, err := obj.ToFlatJSONL([]interface{}{"records"})
+	a.Nil(err, "err is nil")
+	a.Equal(`{"a.b":1,"a.c.0":true,"a.c.1":{}}
+{"d\\.e":null,"f":[]}
+{}
+`, string(b), "lines are correct")
+	a.Equal("", string(MustFromString(`[]`).MustToFlatJSONL(nil)), "empty array has no lines")
+
+	_, err = obj.ToFlatJSONL([]interface{}{"x"})
+	a.Equal("element 0: type assertion to map[string]interface{} failed", err.Error(), "error message is correct")
+	_, err = obj.ToFlatJSONL([]interface{}{"y"})
+	a.NotNil(err, "err is not nil")
+}
+
+func Test_Flatten(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`{"a":[{"b":{"c":1}},{},[],null,"x"],"d.e":{"10":true},"f":{}}`)
+	a.Nil(err, "err is nil")
+
+	flat, err := obj.Flatten()
+	a.Nil(err, "err is nil")
+	a.Equal(map[string]interface{}{
+		"a.0.b.c":  json.Number("1"),
+		"a.1":      map[string]interface{}{},
+		"a.2":      []interface{}{},
+		"a.3":      nil,
+		"a.4":      "x",
+		`d\.e.\10`: true,
+		"f":        map[string]interface{}{},
+	}, flat, "flat is correct")
+	flat["f"].(map[string]interface{})["g"] = 1
+	a.Equal(0, len(obj.MustMap("f")), "values are copies")
+
+	a.Equal(map[string]interface{}{"": "x"}, MustFromString(`"x"`).MustFlatten(), "root scalar has an empty path")
+	_, err = obj.Maybe("nope").Flatten()
+	a.NotNil(err, "err is not nil")
+}
+
+func Test_Unflatten(t *testing.T) {
+	a := assert.New(t)
+
+	for _, str := range []string{
+		`{"a":[{"b":{"c":1}},{},[],null,"x",5,6,7,8,9,{"k":[10,[11]]}],"d.e":{"10":true},"f":{}}`,
+		`[[1],{"a":2},[]]`,
+		`{}`,
+		`[]`,
+		`"x"`,
+		`null`,
+	} {
+		obj := MustFromString(str)
+		res, err := Unflatten(obj.MustFlatten())
+		a.Nil(err, "err is nil")
+		a.Equal(str, res.MustToString(), "round trip is correct")
+	}
+
+	a.Equal(`{"a":{"b":1}}`, MustUnflatten(map[string]interface{}{`a.b`: 1}).MustToString(), "objects are created")
+	a.Equal(`{"x":true}`, MustUnflatten(map[string]interface{}{`\x`: true}).MustToString(), "keys are unescaped")
+	a.Equal(`{}`, MustUnflatten(nil).MustToString(), "nil map is an empty object")
+
+	_, err := Unflatten(map[string]interface{}{"a": 1, "a.b": 2})
+	a.Equal([]interface{}{"a"}, err.(*PathError).FoundPath, "error FoundPath is correct")
+	a.Panics(func() { MustUnflatten(map[string]interface{}{"0": 1, "a": 2}) }, "panics on error")
+}
+
+func Test_Skeleton(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`{"db":{"password":"hunter2","port":5432,"ssl":true,"hosts":["a.b","ñ"],"opts":{},"tags":[],"x":null}}`)
+	a.Nil(err, "err is nil")
+
+	skel := obj.Skeleton()
+	a.Equal(map[string]interface{}{
+		"hosts":    []interface{}{"<string:3>", "<string:1>"},
+		"opts":     map[string]interface{}{},
+		"password": "<string:7>",
+		"port":     "<number>",
+		"ssl":      "<bool>",
+		"tags":     []interface{}{},
+		"x":        "<null>",
+	}, skel.MustMap("db"), "skeleton is correct")
+	a.Equal("hunter2", obj.MustString("db", "password"), "original is not modified")
+	a.Equal("<number>", MustFromString(`1`).Skeleton().MustString(), "root scalar is replaced")
+}
+
+func Test_NormalizeMaps(t *testing.T) {
+	a := assert.New(t)
+
+	obj := FromInterface(map[interface{}]interface{}{
+		"a": []interface{}{map[interface{}]interface{}{1: true, false: "x"}},
+		"b": map[string]interface{}{"c": map[interface{}]interface{}{"d": 2.5}},
+	})
+
+	err := obj.NormalizeMaps()
+	a.Nil(err, "err is nil")
+	obj.MustNormalizeMaps()
+
+	a.True(obj.MustBool("a", 0, "1"), "val is correct")
+	a.Equal("x", obj.MustString("a", 0, "false"), "val is correct")
+	a.Equal(2.5, obj.MustFloat64("b", "c", "d"), "val is correct")
+}
+
+func Test_NormalizeMaps_Error(t *testing.T) {
+	a := assert.New(t)
+
+	obj := FromInterface(map[string]interface{}{
+		"a": map[interface{}]interface{}{struct{}{}: 1},
+	})
+	err := obj.NormalizeMaps()
+	a.Equal(`none stringifiable key {} of type struct {} at "a"`, err.Error(), "error message is correct")
+
+	obj = FromInterface(map[interface{}]interface{}{1: 1, "1": 2})
+	err = obj.NormalizeMaps()
+	a.Equal(`duplicate key "1" at ""`, err.Error(), "error message is correct")
+}
+
+func Test_Head(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`{"c":[1,2,3],"a":{"z":1,"y":[4,5,6],"x":3},"b":true}`)
+	a.Nil(err, "err is nil")
+
+	str, err := obj.Head(2).ToString()
+	a.Nil(err, "err is nil")
+	a.Equal(`{"a":{"x":3,"y":[4,5]},"b":true}`, str, "str is correct value")
+
+	str, err = obj.Head(0).ToString()
+	a.Nil(err, "err is nil")
+	a.Equal(`{}`, str, "str is correct value")
+
+	obj.Head(5).MustSet("c", 0, "changed")
+	a.Equal(1, obj.MustInt("c", 0), "source is not modified")
+}
+
+func Test_GrepValues(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`{"b":["an Error occurred",{"c":"error"}],"a":"fine","d":"ERROR"}`)
+	a.Nil(err, "err is nil")
+
+	a.Equal([][]interface{}{{"b", 1, "c"}}, obj.GrepValues("error", false), "paths is correct")
+	a.Equal([][]interface{}{{"b", 0}, {"b", 1, "c"}, {"d"}}, obj.GrepValues("eRRor", true), "paths is correct")
+	a.Equal([][]interface{}{}, obj.GrepValues("missing", true), "paths is empty")
+}
+
+func Test_ReplaceStrings(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`{"a":{"url":"http://old.host/x"},"b":["old.host",["old.host:80"]],"c":"new.host"}`)
+	a.Nil(err, "err is nil")
+
+	n := obj.ReplaceStrings(regexp.MustCompile(`old\.host`), "new.host")
+	a.Equal(3, n, "n is correct")
+	a.Equal(`{"a":{"url":"http://new.host/x"},"b":["new.host",["new.host:80"]],"c":"new.host"}`, obj.MustToString(), "str is correct value")
+
+	root := FromInterface("old")
+	a.Equal(1, root.ReplaceStrings(regexp.MustCompile(`o`), "0"), "n is correct")
+	a.Equal("0ld", root.MustString(), "root is replaced")
+}
+
+func Test_PrecisionRisks(t *testing.T) {
+	a := assert.New(t)
+
+	obj, err := FromString(`{"id":9007199254740993,"ok":9007199254740992,"money":[0.1,19.99,0.12345678901234567890],"big":1e400,"f":1.5e3}`)
+	a.Nil(err, "err is nil")
+
+	a.Equal([][]interface{}{{"big"}, {"id"}, {"money", 2}}, obj.PrecisionRisks(), "paths is correct")
+}
@@ -0,0 +1,66 @@
+package json
+
+import (
+	"context"
+	"os"
+	"time"
+)
+
+var (
+	watchPollInterval = 250 * time.Millisecond
+	watchDebounce     = 500 * time.Millisecond
+)
+
+// WatchFile polls `file` for modifications until `ctx` is done, reparsing it once its
+// size and modification time have been stable for a short debounce period and calling
+// `onChange` with the new document and its Changes from the previous version.
+// Modifications that fail to parse or that result in no changes are ignored.
+// WatchFile blocks, returning an error if `file` can not be read initially and
+// `ctx.Err()` once `ctx` is done.
+//		go json.WatchFile(ctx, "config.json", func(cfg *json.Json, changes []json.Change) {
+//			...
+//		})
+func WatchFile(ctx context.Context, file string, onChange func(*Json, []Change)) error {
+	prev, err := FromFile(file)
+	if err != nil {
+		return err
+	}
+	lastStat, err := os.Stat(file)
+	if err != nil {
+		return err
+	}
+	ticker := time.NewTicker(watchPollInterval)
+	defer ticker.Stop()
+	pending := false
+	var lastModified time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			stat, err := os.Stat(file)
+			if err != nil {
+				continue
+			}
+			if !stat.ModTime().Equal(lastStat.ModTime()) || stat.Size() != lastStat.Size() {
+				lastStat = stat
+				lastModified = time.Now()
+				pending = true
+				continue
+			}
+			if !pending || time.Since(lastModified) < watchDebounce {
+				continue
+			}
+			pending = false
+			next, err := FromFile(file)
+			if err != nil {
+				continue
+			}
+			changes := next.Changes(prev)
+			prev = next
+			if len(changes) > 0 {
+				onChange(next, changes)
+			}
+		}
+	}
+}
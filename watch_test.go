@@ -0,0 +1,62 @@
+package json
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func Test_WatchFile(t *testing.T) {
+	a := assert.New(t)
+
+	watchPollInterval, watchDebounce = 5*time.Millisecond, 20*time.Millisecond
+	defer func() {
+		watchPollInterval, watchDebounce = 250*time.Millisecond, 500*time.Millisecond
+	}()
+
+	dir, err := ioutil.TempDir("", "watch")
+	a.Nil(err, "err is nil")
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "test.json")
+	a.Nil(ioutil.WriteFile(file, []byte(`{"a":1}`), os.ModePerm), "err is nil")
+
+	type update struct {
+		js      *Json
+		changes []Change
+	}
+	updates := make(chan update, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- WatchFile(ctx, file, func(js *Json, changes []Change) {
+			updates <- update{js, changes}
+		})
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	a.Nil(ioutil.WriteFile(file, []byte(`{"a":`), os.ModePerm), "err is nil")
+	a.Nil(ioutil.WriteFile(file, []byte(`{"a":22}`), os.ModePerm), "err is nil")
+
+	select {
+	case u := <-updates:
+		a.Equal(22, u.js.MustInt("a"), "js is the new document")
+		a.Equal(1, len(u.changes), "changes length is correct")
+		a.Equal([]interface{}{"a"}, u.changes[0].Path, "change path is correct")
+	case <-time.After(5 * time.Second):
+		a.Fail("timed out waiting for change")
+	}
+
+	cancel()
+	a.Equal(context.Canceled, <-done, "err is context.Canceled")
+}
+
+func Test_WatchFile_MissingFile(t *testing.T) {
+	a := assert.New(t)
+
+	err := WatchFile(context.Background(), "missing.json", func(*Json, []Change) {})
+	a.True(os.IsNotExist(err), "err is a not exists error")
+}